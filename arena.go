@@ -0,0 +1,188 @@
+package mtg
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var arenaLine = regexp.MustCompile(`^(\d+)\s+(.+?)(?:\s+\(([0-9A-Za-z_]+)\)(?:\s+(\S+))?)?$`)
+
+var (
+	arenaPrintingLine = regexp.MustCompile(`^\d+\s+.+\s+\([0-9A-Za-z_]+\)\s+\S+$`)
+	textMarkup        = regexp.MustCompile(`[\[#]|(^|\s)![A-Za-z]`)
+)
+
+// ParseArenaDeck parses a deck exported by MTG Arena, e.g.:
+//
+//	Deck
+//	4 Lightning Strike (M19) 152
+//
+//	Sideboard
+//	2 Shock (M19) 156
+func ParseArenaDeck(in io.Reader, opts ...ParseOption) (Deck, error) {
+	p := &deckParser{}
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return Deck{}, err
+		}
+	}
+
+	deck := Deck{}
+	var errs ParseErrors
+	current := Main
+	structured := false
+	blank := false
+	about := false
+	lineNumber := 0
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		lineNumber++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			blank = true
+			continue
+		}
+		if strings.EqualFold(line, "About") {
+			structured = true
+			about = true
+			continue
+		}
+		if name, ok := SectionName(line); ok {
+			structured = true
+			about = false
+			current = name
+			blank = false
+			continue
+		}
+		if about {
+			if strings.HasPrefix(line, "Name ") {
+				deck.Name = strings.TrimSpace(line[len("Name "):])
+			}
+			continue
+		}
+		if blank && !structured && current == Main && len(deck.Sections) > 0 {
+			current = Sideboard
+		}
+		blank = false
+		m := arenaLine.FindStringSubmatch(line)
+		if m == nil {
+			err := &ParseError{Line: lineNumber, Text: raw, Reason: "want: COUNT NAME (SET) NUMBER"}
+			if p.strict {
+				return Deck{}, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		c, _ := strconv.Atoi(m[1])
+		if c < 1 {
+			err := &ParseError{Line: lineNumber, Text: raw, Reason: "count must be positive"}
+			if p.strict {
+				return Deck{}, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		card := Card{Name: m[2]}
+		if m[3] != "" {
+			card.Version = &Version{Set: m[3], CollectorNumber: m[4]}
+		}
+		s := deck.Section(current)
+		s.Entries = append(s.Entries, Entry{Count: c, Card: card, Line: lineNumber})
+	}
+	if err := scanner.Err(); err != nil {
+		return Deck{}, err
+	}
+	if len(deck.Sections) == 0 {
+		deck.Sections = append(deck.Sections, Section{Name: Main})
+	}
+	if len(errs) > 0 {
+		return deck, errs
+	}
+	return deck, nil
+}
+
+var arenaSections = []struct {
+	name   string
+	header string
+}{
+	{Commander, "Commander"},
+	{Companion, "Companion"},
+	{Main, "Deck"},
+	{Sideboard, "Sideboard"},
+}
+
+// WriteArenaDeck writes the deck in a format that can be imported by MTG Arena.
+// Sections that are unknown to Arena, like the maybeboard, are omitted.
+func WriteArenaDeck(w io.Writer, d Deck) error {
+	bw := bufio.NewWriter(w)
+	first := true
+	if d.Name != "" {
+		fmt.Fprintf(bw, "About\nName %s\n", d.Name)
+		first = false
+	}
+	for _, as := range arenaSections {
+		var entries []Entry
+		for _, s := range d.Sections {
+			if s.Name == as.name {
+				entries = append(entries, s.Entries...)
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		if !first {
+			fmt.Fprintln(bw)
+		}
+		first = false
+		fmt.Fprintln(bw, as.header)
+		for _, e := range entries {
+			fmt.Fprintf(bw, "%d %s", e.Count, e.Card.Name)
+			if v := e.Card.Version; v != nil && v.Set != "" && v.CollectorNumber != "" {
+				fmt.Fprintf(bw, " (%s) %s", strings.ToUpper(v.Set), v.CollectorNumber)
+			}
+			fmt.Fprintln(bw)
+		}
+	}
+	return bw.Flush()
+}
+
+// sniffArena reports whether a file looks like an Arena export: it has an About
+// or Deck header, or every card has a set and a collector number. Files with
+// printings in brackets, tags or comments are plain text.
+func sniffArena(head []byte) bool {
+	header, printings, cards := false, true, 0
+	about := false
+	for _, l := range strings.Split(string(head), "\n") {
+		l = strings.TrimSpace(l)
+		switch {
+		case l == "":
+			continue
+		case strings.EqualFold(l, "About"):
+			header, about = true, true
+			continue
+		case strings.EqualFold(l, "Deck"):
+			header, about = true, false
+			continue
+		}
+		if _, ok := SectionName(l); ok {
+			about = false
+			continue
+		}
+		if about {
+			continue
+		}
+		if textMarkup.MatchString(l) {
+			return false
+		}
+		if !arenaPrintingLine.MatchString(l) {
+			printings = false
+		}
+		cards++
+	}
+	return header || printings && cards > 0
+}
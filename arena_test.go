@@ -0,0 +1,77 @@
+package mtg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseArenaDeck(t *testing.T) {
+	raw := `About
+Name Mono Red
+
+Companion
+1 Lurrus of the Dream-Den (IKO) 226
+
+Deck
+4 Lightning Strike (M19) 152
+20 Mountain
+
+Sideboard
+2 Shock (M19) 156
+`
+
+	d, err := ParseArenaDeck(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Name != "Mono Red" {
+		t.Errorf("want: %q, got: %q", "Mono Red", d.Name)
+	}
+	want := map[string]int{Companion: 1, Main: 24, Sideboard: 2}
+	if len(d.Sections) != len(want) {
+		t.Fatalf("want: %d, got: %d", len(want), len(d.Sections))
+	}
+	for _, s := range d.Sections {
+		if s.Count() != want[s.Name] {
+			t.Errorf("%s: want: %d, got: %d", s.Name, want[s.Name], s.Count())
+		}
+	}
+	e := d.Section(Main).Entries[0]
+	if e.Card.Name != "Lightning Strike" {
+		t.Errorf("want: %q, got: %q", "Lightning Strike", e.Card.Name)
+	}
+	if e.Card.Version == nil || *e.Card.Version != (Version{Set: "M19", CollectorNumber: "152"}) {
+		t.Errorf("want: %v, got: %v", Version{Set: "M19", CollectorNumber: "152"}, e.Card.Version)
+	}
+	if v := d.Section(Main).Entries[1].Card.Version; v != nil {
+		t.Errorf("want: no version, got: %v", v)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteArenaDeck(buf, d); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != raw {
+		t.Errorf("want:\n%s\ngot:\n%s", raw, got)
+	}
+}
+
+func TestParseArenaDeckWithoutHeaders(t *testing.T) {
+	raw := `4 Lightning Strike (M19) 152
+20 Mountain
+
+2 Shock (M19) 156
+`
+
+	d, err := ParseArenaDeck(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := d.Section(Main).Count(); n != 24 {
+		t.Errorf("want: %d, got: %d", 24, n)
+	}
+	if n := d.Section(Sideboard).Count(); n != 2 {
+		t.Errorf("want: %d, got: %d", 2, n)
+	}
+}
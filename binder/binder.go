@@ -0,0 +1,304 @@
+// Package binder keeps track of the originals of a Staples Binder: the cards
+// that are owned once and proxied in every deck that plays them. Before a game
+// the originals are checked out of the binder into a deck and returned after it.
+package binder
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cognicraft/archive"
+	"github.com/cognicraft/mtg"
+)
+
+const (
+	prefixOriginals = "binder/originals/"
+	prefixDecks     = "binder/decks/"
+)
+
+// An Original is a card of the binder and the number of copies that are
+// currently checked out into decks.
+type Original struct {
+	Name  string         `json:"name"`
+	Owned int            `json:"owned"`
+	Decks map[string]int `json:"decks,omitempty"`
+}
+
+// InBinder returns the number of copies that are not checked out.
+func (o Original) InBinder() int {
+	n := o.Owned
+	for _, c := range o.Decks {
+		n -= c
+	}
+	return n
+}
+
+func (o Original) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d owned, %d in the binder", o.Name, o.Owned, o.InBinder())
+	for _, deck := range o.deckNames() {
+		fmt.Fprintf(&b, ", %d in %s", o.Decks[deck], deck)
+	}
+	return b.String()
+}
+
+func (o Original) deckNames() []string {
+	var names []string
+	for name := range o.Decks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// A Move is a number of originals that have been moved between the binder
+// and a deck.
+type Move struct {
+	Name  string
+	Count int
+	Deck  string
+	// Return is true if the originals have been returned to the binder.
+	Return bool
+}
+
+func (m Move) String() string {
+	if m.Return {
+		return fmt.Sprintf("%d %s: %s -> binder", m.Count, m.Name, m.Deck)
+	}
+	return fmt.Sprintf("%d %s: binder -> %s", m.Count, m.Name, m.Deck)
+}
+
+// A Binder stores the originals and the registered decks in an archive.
+type Binder struct {
+	store *archive.Archive
+}
+
+func New(store *archive.Archive) *Binder {
+	return &Binder{store: store}
+}
+
+func originalID(name string) string {
+	return prefixOriginals + strings.ToLower(name)
+}
+
+// Where returns the original of a card, i.e. how many copies are in the binder
+// and in which decks the others are. Cards that are not in the binder are
+// returned with no owned copies.
+func (b *Binder) Where(name string) (Original, error) {
+	o := Original{}
+	err := archive.LoadJSON(b.store, originalID(name), &o)
+	switch err {
+	case nil:
+		return o, nil
+	case sql.ErrNoRows:
+		return Original{Name: name}, nil
+	}
+	return Original{}, err
+}
+
+func (b *Binder) storeOriginal(o Original) error {
+	if o.Owned == 0 && len(o.Decks) == 0 {
+		return b.store.Delete(originalID(o.Name))
+	}
+	return b.store.Store(archive.GenericJSON(originalID(o.Name), o))
+}
+
+// Originals returns all originals of the binder ordered by name.
+func (b *Binder) Originals() ([]Original, error) {
+	ds, err := b.store.ListWithPrefix(prefixOriginals)
+	if err != nil {
+		return nil, err
+	}
+	var originals []Original
+	for _, d := range ds {
+		o := Original{}
+		if err := archive.LoadJSON(b.store, d.ID, &o); err != nil {
+			return nil, err
+		}
+		originals = append(originals, o)
+	}
+	return originals, nil
+}
+
+// Add adds owned copies of a card to the binder.
+func (b *Binder) Add(name string, n int) error {
+	o, err := b.Where(name)
+	if err != nil {
+		return err
+	}
+	if o.Owned == 0 {
+		o.Name = name
+	}
+	o.Owned += n
+	return b.storeOriginal(o)
+}
+
+// Remove removes owned copies of a card that are in the binder.
+func (b *Binder) Remove(name string, n int) error {
+	o, err := b.Where(name)
+	if err != nil {
+		return err
+	}
+	if o.InBinder() < n {
+		return fmt.Errorf("cannot remove %d %s, only %d in the binder", n, name, o.InBinder())
+	}
+	o.Owned -= n
+	return b.storeOriginal(o)
+}
+
+// Register adds a deck to the registry or replaces a deck with the same name.
+func (b *Binder) Register(d mtg.Deck) error {
+	if d.Name == "" {
+		return fmt.Errorf("cannot register a deck without a name")
+	}
+	buf := &bytes.Buffer{}
+	if err := mtg.WriteDeck(buf, d); err != nil {
+		return err
+	}
+	return b.store.Store(archive.TextPlain(prefixDecks+d.Name, buf.String()))
+}
+
+// Unregister removes a deck from the registry. The originals of the deck must
+// have been returned.
+func (b *Binder) Unregister(name string) error {
+	originals, err := b.Originals()
+	if err != nil {
+		return err
+	}
+	for _, o := range originals {
+		if o.Decks[name] > 0 {
+			return fmt.Errorf("deck %s still holds originals, return them first", name)
+		}
+	}
+	return b.store.Delete(prefixDecks + name)
+}
+
+// Deck returns a registered deck.
+func (b *Binder) Deck(name string) (mtg.Deck, error) {
+	r, err := b.store.Load(prefixDecks + name)
+	if err != nil {
+		return mtg.Deck{}, fmt.Errorf("unknown deck: %s", name)
+	}
+	d, err := mtg.ParseDeck(bytes.NewReader(r.Data))
+	if err != nil {
+		return mtg.Deck{}, err
+	}
+	d.Name = name
+	return d, nil
+}
+
+// Decks returns the names of the registered decks.
+func (b *Binder) Decks() ([]string, error) {
+	ds, err := b.store.ListWithPrefix(prefixDecks)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, d := range ds {
+		names = append(names, strings.TrimPrefix(d.ID, prefixDecks))
+	}
+	return names, nil
+}
+
+// needs returns the number of copies of each card the deck plays, ignoring the
+// maybeboard.
+func needs(d mtg.Deck) (map[string]int, []string) {
+	counts := map[string]int{}
+	var names []string
+	for _, s := range d.Sections {
+		if s.Name == mtg.Maybeboard {
+			continue
+		}
+		for _, e := range s.Entries {
+			if _, ok := counts[e.Card.Name]; !ok {
+				names = append(names, e.Card.Name)
+			}
+			counts[e.Card.Name] += e.Count
+		}
+	}
+	sort.Strings(names)
+	return counts, names
+}
+
+// CheckOut moves as many originals as the deck plays and the binder holds
+// into a registered deck.
+func (b *Binder) CheckOut(deck string) ([]Move, error) {
+	d, err := b.Deck(deck)
+	if err != nil {
+		return nil, err
+	}
+	counts, names := needs(d)
+	var ms []Move
+	for _, name := range names {
+		o, err := b.Where(name)
+		if err != nil {
+			return ms, err
+		}
+		n := counts[name] - o.Decks[deck]
+		if n > o.InBinder() {
+			n = o.InBinder()
+		}
+		if n <= 0 {
+			continue
+		}
+		if o.Decks == nil {
+			o.Decks = map[string]int{}
+		}
+		o.Decks[deck] += n
+		if err := b.storeOriginal(o); err != nil {
+			return ms, err
+		}
+		ms = append(ms, Move{Name: o.Name, Count: n, Deck: deck})
+	}
+	return ms, nil
+}
+
+// Return moves all originals of a deck back into the binder.
+func (b *Binder) Return(deck string) ([]Move, error) {
+	originals, err := b.Originals()
+	if err != nil {
+		return nil, err
+	}
+	var ms []Move
+	for _, o := range originals {
+		n := o.Decks[deck]
+		if n == 0 {
+			continue
+		}
+		delete(o.Decks, deck)
+		if err := b.storeOriginal(o); err != nil {
+			return ms, err
+		}
+		ms = append(ms, Move{Name: o.Name, Count: n, Deck: deck, Return: true})
+	}
+	return ms, nil
+}
+
+// Proxies returns the cards of the binder a registered deck plays more copies
+// of than there are originals in the binder or already in the deck. Cards that
+// are not in the binder are expected to be owned by the deck itself.
+func (b *Binder) Proxies(deck string) (mtg.Deck, error) {
+	d, err := b.Deck(deck)
+	if err != nil {
+		return mtg.Deck{}, err
+	}
+	counts, names := needs(d)
+	proxies := mtg.Deck{Name: deck}
+	main := proxies.Section(mtg.Main)
+	for _, name := range names {
+		o, err := b.Where(name)
+		if err != nil {
+			return mtg.Deck{}, err
+		}
+		if o.Owned == 0 {
+			continue
+		}
+		if n := counts[name] - o.InBinder() - o.Decks[deck]; n > 0 {
+			main.Entries = append(main.Entries, mtg.Entry{Count: n, Card: mtg.Card{Name: name}})
+		}
+	}
+	return proxies, nil
+}
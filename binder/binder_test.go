@@ -0,0 +1,96 @@
+package binder
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cognicraft/archive"
+	"github.com/cognicraft/mtg"
+)
+
+func TestBinder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "binder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := archive.Open(filepath.Join(dir, "binder.arc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	b := New(store)
+
+	mustParse := func(name string, text string) mtg.Deck {
+		d, err := mtg.ParseDeck(strings.NewReader(text))
+		if err != nil {
+			t.Fatal(err)
+		}
+		d.Name = name
+		return d
+	}
+	if err := b.Register(mustParse("Burn", "4 Ragavan, Nimble Pilferer\n4 Lightning Bolt\n20 Mountain\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Register(mustParse("Dragons", "2 Ragavan, Nimble Pilferer\n2 Lightning Bolt\n//Maybeboard\n4 Ragavan, Nimble Pilferer\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Add("Ragavan, Nimble Pilferer", 4); err != nil {
+		t.Fatal(err)
+	}
+
+	moves, err := b.CheckOut("Dragons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "[2 Ragavan, Nimble Pilferer: binder -> Dragons]", fmt.Sprint(moves); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	o, err := b.Where("ragavan, nimble pilferer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "Ragavan, Nimble Pilferer: 4 owned, 2 in the binder, 2 in Dragons", o.String(); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	proxies, err := b.Proxies("Burn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := 2, proxies.Count(); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	if err := b.Unregister("Dragons"); err == nil {
+		t.Errorf("want: error unregistering a deck holding originals, got: nil")
+	}
+
+	moves, err = b.Return("Dragons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "[2 Ragavan, Nimble Pilferer: Dragons -> binder]", fmt.Sprint(moves); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	moves, err = b.CheckOut("Burn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "[4 Ragavan, Nimble Pilferer: binder -> Burn]", fmt.Sprint(moves); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	proxies, err = b.Proxies("Dragons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := 2, proxies.Count(); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	if err := b.Remove("Ragavan, Nimble Pilferer", 1); err == nil {
+		t.Errorf("want: error removing a checked out original, got: nil")
+	}
+}
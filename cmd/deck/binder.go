@@ -0,0 +1,145 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cognicraft/archive"
+	"github.com/cognicraft/mtg"
+	"github.com/cognicraft/mtg/binder"
+)
+
+const binderUsage = `usage: deck binder [flags] <command> [arguments]
+
+commands:
+  add <count> <card>       add owned originals to the binder
+  remove <count> <card>    remove originals from the binder
+  list                     list the originals and where they are
+  where <card>             show where the originals of a card are
+  register <deck>          register a deck file, named by -name or the file name
+  unregister <name>        remove a deck from the registry
+  decks                    list the registered decks
+  checkout <name>          move originals from the binder into a deck
+  return <name>            move the originals of a deck back into the binder
+  proxies <name>           write the proxies a deck needs for what is in the binder
+
+flags:
+`
+
+func runBinder(args []string) error {
+	fs := flag.NewFlagSet("binder", flag.ExitOnError)
+	binderFile := fs.String("binder", "binder.arc", "Binder")
+	name := fs.String("name", "", "Name of the deck to register")
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, binderUsage)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() < 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	cmd, args := fs.Arg(0), fs.Args()[1:]
+
+	store, err := archive.Open(*binderFile)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	b := binder.New(store)
+
+	argN := func(n int) {
+		if len(args) < n {
+			fs.Usage()
+			store.Close()
+			os.Exit(2)
+		}
+	}
+	countAndCard := func() (int, string, error) {
+		argN(2)
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return 0, "", fmt.Errorf("invalid count: %s", args[0])
+		}
+		return n, strings.Join(args[1:], " "), nil
+	}
+	printMoves := func(ms []binder.Move, err error) error {
+		for _, m := range ms {
+			fmt.Println(m)
+		}
+		return err
+	}
+
+	switch cmd {
+	case "add":
+		n, card, err := countAndCard()
+		if err != nil {
+			return err
+		}
+		return b.Add(card, n)
+	case "remove":
+		n, card, err := countAndCard()
+		if err != nil {
+			return err
+		}
+		return b.Remove(card, n)
+	case "list":
+		originals, err := b.Originals()
+		if err != nil {
+			return err
+		}
+		for _, o := range originals {
+			fmt.Println(o)
+		}
+	case "where":
+		argN(1)
+		o, err := b.Where(strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+		fmt.Println(o)
+	case "register":
+		argN(1)
+		d, err := readDeck(args[0], "")
+		if err != nil {
+			return err
+		}
+		d.Name = *name
+		if d.Name == "" {
+			d.Name = deckName(args[0])
+		}
+		return b.Register(d)
+	case "unregister":
+		argN(1)
+		return b.Unregister(args[0])
+	case "decks":
+		names, err := b.Decks()
+		if err != nil {
+			return err
+		}
+		for _, n := range names {
+			fmt.Println(n)
+		}
+	case "checkout":
+		argN(1)
+		return printMoves(b.CheckOut(args[0]))
+	case "return":
+		argN(1)
+		return printMoves(b.Return(args[0]))
+	case "proxies":
+		argN(1)
+		d, err := b.Proxies(args[0])
+		if err != nil {
+			return err
+		}
+		return mtg.WriteDeck(os.Stdout, d)
+	default:
+		fs.Usage()
+		store.Close()
+		os.Exit(2)
+	}
+	return nil
+}
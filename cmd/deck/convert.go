@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cognicraft/mtg"
+)
+
+func runConvert(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	from := fs.String("from", "", "Input format (default: detected)")
+	to := fs.String("to", "", "Output format (default: detected from the output file, or text)")
+	out := fs.String("o", "-", "Output file")
+	canonical := fs.Bool("canonical", false, "Canonicalise card names through scryfall")
+	printings := fs.Bool("printings", false, "Canonicalise card names and fill in the printings through scryfall")
+	merge := fs.Bool("merge", false, "Merge entries of the same card")
+	sorted := fs.Bool("sort", false, "Sort entries by name")
+	strict := fs.Bool("strict", false, "Fail on lines of the deck that cannot be parsed")
+	cacheFile := fs.String("cache", "cache.arc", "Cache")
+	debug := fs.Bool("debug", false, "Debug?")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: deck convert [flags] <deck>\n\nformats: %s\n\nflags:\n", codecNames())
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	var opts []mtg.ParseOption
+	if *strict {
+		opts = append(opts, mtg.Strict())
+	}
+	deck, err := readDeck(fs.Arg(0), *from, opts...)
+	if err != nil {
+		return err
+	}
+
+	if *canonical || *printings || hasMtgoIDs(deck) {
+		client, closeCache, err := openScryfall(*cacheFile, *debug)
+		if err != nil {
+			return err
+		}
+		defer closeCache()
+		deck, err = mtg.ResolveMtgoIDs(client, deck)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: %v\n", err)
+		}
+		if *canonical || *printings {
+			var ropts []mtg.ResolverOption
+			if *printings {
+				ropts = append(ropts, mtg.ResolvePrintings())
+			}
+			var rs []mtg.Resolution
+			deck, rs = mtg.NewResolver(client, ropts...).Resolve(deck)
+			mtg.WriteResolutions(os.Stderr, rs)
+		}
+	}
+	if *merge {
+		deck.Merge()
+	}
+	if *sorted {
+		deck.Sort()
+	}
+
+	var codec mtg.Codec
+	if *to != "" {
+		c, ok := mtg.CodecByName(*to)
+		if !ok {
+			return fmt.Errorf("unknown format: %s (known: %s)", *to, codecNames())
+		}
+		codec = c
+	} else {
+		codec = mtg.DetectCodec(*out, nil)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return codec.Encode(w, deck)
+}
+
+func hasMtgoIDs(d mtg.Deck) bool {
+	for _, c := range d.Cards() {
+		if c.Name == "" && c.MtgoID != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cognicraft/mtg"
+)
+
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	from := fs.String("from", "", "Format of both decks (default: detected)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: deck diff [flags] <old deck> <new deck>\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	a, err := readDeck(fs.Arg(0), *from)
+	if err != nil {
+		return err
+	}
+	b, err := readDeck(fs.Arg(1), *from)
+	if err != nil {
+		return err
+	}
+	cs := mtg.DiffDecks(a, b)
+	if err := mtg.WriteDiff(os.Stdout, cs); err != nil {
+		return err
+	}
+	if len(cs) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cognicraft/archive"
+	"github.com/cognicraft/mtg"
+	"github.com/cognicraft/mtg/scryfall"
+)
+
+var version = "0.1"
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"binder":   {"track the originals of a staples binder", runBinder},
+	"convert":  {"convert a deck from one format to another", runConvert},
+	"diff":     {"show the changes between two versions of a deck", runDiff},
+	"need":     {"list the cards of a deck that are not in a collection", runNeed},
+	"plan":     {"plan the originals and proxies of decks sharing a staples binder", runPlan},
+	"price":    {"show the price of a deck", runPrice},
+	"resolve":  {"report and correct misspelled card names", runResolve},
+	"stats":    {"show the mana curve, colors and types of a deck", runStats},
+	"validate": {"check a deck against the rules of a format", runValidate},
+}
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	switch name {
+	case "version", "-version", "--version":
+		fmt.Printf("%s\n", version)
+		return
+	case "help", "-h", "-help", "--help":
+		usage()
+		return
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: deck <command> [arguments]\n\ncommands:\n")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
+
+func openScryfall(cacheFile string, debug bool, scOpts ...scryfall.ClientOption) (*scryfall.Client, func(), error) {
+	cache, err := archive.Open(cacheFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts := append([]scryfall.ClientOption{scryfall.Cache(cache)}, scOpts...)
+	if debug {
+		opts = append(opts, scryfall.Debug())
+	}
+	client, err := scryfall.New(opts...)
+	if err != nil {
+		cache.Close()
+		return nil, nil, err
+	}
+	return client, func() { cache.Close() }, nil
+}
+
+// readDeck reads a deck from a file, or from stdin if the file name is "-".
+// If format is empty the format is detected.
+func readDeck(fileName string, format string, opts ...mtg.ParseOption) (mtg.Deck, error) {
+	var in io.Reader = os.Stdin
+	if fileName != "-" {
+		f, err := os.Open(fileName)
+		if err != nil {
+			return mtg.Deck{}, err
+		}
+		defer f.Close()
+		in = f
+	}
+	var deck mtg.Deck
+	var err error
+	if format == "" {
+		deck, err = mtg.ReadDeck(fileName, in, opts...)
+	} else {
+		c, ok := mtg.CodecByName(format)
+		if !ok {
+			return mtg.Deck{}, fmt.Errorf("unknown format: %s (known: %s)", format, codecNames())
+		}
+		deck, err = c.Decode(in, opts...)
+	}
+	if errs, ok := err.(mtg.ParseErrors); ok {
+		for _, e := range errs {
+			fmt.Fprintf(os.Stderr, "WARNING: %s: dropped %v\n", fileName, e)
+		}
+		err = nil
+	}
+	return deck, err
+}
+
+func codecNames() string {
+	var names []string
+	for _, c := range mtg.Codecs() {
+		names = append(names, c.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// deckName returns the name of a deck file without directory and extension.
+func deckName(fileName string) string {
+	base := filepath.Base(fileName)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cognicraft/mtg"
+)
+
+func runNeed(args []string) error {
+	fs := flag.NewFlagSet("need", flag.ExitOnError)
+	collectionFile := fs.String("collection", "", "Collection file in any supported format")
+	shared := fs.String("shared", "", "Comma separated list of deck files that share the collection")
+	proxies := fs.Bool("proxies", false, "Write the proxy list instead of the shopping list")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: deck need -collection <collection> [flags] <deck>\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 || *collectionFile == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	c, err := mtg.ReadCollectionFile(*collectionFile)
+	if errs, ok := err.(mtg.ParseErrors); ok {
+		for _, e := range errs {
+			fmt.Fprintf(os.Stderr, "WARNING: %s: dropped %v\n", *collectionFile, e)
+		}
+	} else if err != nil {
+		return err
+	}
+	deck, err := readDeck(fs.Arg(0), "")
+	if err != nil {
+		return err
+	}
+	var others []mtg.Deck
+	if *shared != "" {
+		for _, fileName := range strings.Split(*shared, ",") {
+			d, err := readDeck(fileName, "")
+			if err != nil {
+				return err
+			}
+			others = append(others, d)
+		}
+	}
+
+	buy, proxy := c.Subtract(deck, others...)
+	if *proxies {
+		return mtg.WriteDeck(os.Stdout, proxy)
+	}
+	return mtg.WriteDeck(os.Stdout, buy)
+}
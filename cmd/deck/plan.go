@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cognicraft/mtg"
+)
+
+func runPlan(args []string) error {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	pdf := fs.String("pdf", "", "Write the proxies of all decks, labeled with the deck names, to a PDF file")
+	format := fs.String("format", "image", "Format of the proxies: image or text")
+	cacheFile := fs.String("cache", "cache.arc", "Cache")
+	debug := fs.Bool("debug", false, "Debug?")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: deck plan [flags] <deck> <deck>...\n\nThe decks are named by their file names.\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() < 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	var decks []mtg.Deck
+	fileNames := map[string]string{}
+	for _, fileName := range fs.Args() {
+		d, err := readDeck(fileName, "")
+		if err != nil {
+			return err
+		}
+		d.Name = deckName(fileName)
+		if other, ok := fileNames[d.Name]; ok {
+			return fmt.Errorf("%s and %s are both named %s", other, fileName, d.Name)
+		}
+		fileNames[d.Name] = fileName
+		decks = append(decks, d)
+	}
+	plan := mtg.PlanStaples(decks...)
+	if err := mtg.WritePlan(os.Stdout, plan); err != nil {
+		return err
+	}
+	if *pdf == "" {
+		return nil
+	}
+
+	client, closeCache, err := openScryfall(*cacheFile, *debug)
+	if err != nil {
+		return err
+	}
+	defer closeCache()
+	p := mtg.NewProxyPrinter(client, mtg.PlanProxies(plan, decks...))
+	switch *format {
+	case "text":
+		err = p.WriteTextProxiesToFile(*pdf)
+	default:
+		err = p.WriteImageProxiesToFile(*pdf)
+	}
+	if nf, ok := err.(*mtg.NotFoundError); ok {
+		fmt.Fprintf(os.Stderr, "WARNING: %v\n", nf)
+		return nil
+	}
+	return err
+}
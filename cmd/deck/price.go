@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cognicraft/mtg"
+	"github.com/cognicraft/mtg/scryfall"
+)
+
+// priceMaxAge is the age after which cached prices are refetched.
+const priceMaxAge = 24 * time.Hour
+
+func runPrice(args []string) error {
+	fs := flag.NewFlagSet("price", flag.ExitOnError)
+	currency := fs.String("currency", "usd", "Currency: usd, eur or tix")
+	cheapest := fs.Bool("cheapest", false, "Price cards without a printing by their cheapest printing")
+	cacheFile := fs.String("cache", "cache.arc", "Cache")
+	debug := fs.Bool("debug", false, "Debug?")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: deck price [flags] <deck>\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	switch mtg.Currency(*currency) {
+	case mtg.USD, mtg.EUR, mtg.TIX:
+	default:
+		return fmt.Errorf("unknown currency: %s", *currency)
+	}
+
+	deck, err := readDeck(fs.Arg(0), "")
+	if err != nil {
+		return err
+	}
+	client, closeCache, err := openScryfall(*cacheFile, *debug, scryfall.MaxAge(priceMaxAge))
+	if err != nil {
+		return err
+	}
+	defer closeCache()
+
+	var opts []mtg.PriceOption
+	if *cheapest {
+		opts = append(opts, mtg.Cheapest())
+	}
+	report, err := mtg.PriceDeck(client, deck, mtg.Currency(*currency), opts...)
+	if nf, ok := err.(*mtg.NotFoundError); ok {
+		fmt.Fprintf(os.Stderr, "WARNING: %v\n", nf)
+	} else if err != nil {
+		return err
+	}
+	return mtg.WritePriceReport(os.Stdout, report)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/cognicraft/mtg"
+)
+
+func runResolve(args []string) error {
+	fs := flag.NewFlagSet("resolve", flag.ExitOnError)
+	write := fs.Bool("w", false, "Rewrite the deck with the canonical card names")
+	printings := fs.Bool("printings", false, "Fill in the printings of all cards")
+	cacheFile := fs.String("cache", "cache.arc", "Cache")
+	debug := fs.Bool("debug", false, "Debug?")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: deck resolve [flags] <deck>\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	fileName := fs.Arg(0)
+
+	var codec mtg.Codec
+	if *write && fileName != "-" {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return err
+		}
+		if codec, err = rewritable(fileName, data); err != nil {
+			return err
+		}
+	}
+
+	deck, err := readDeck(fileName, "")
+	if err != nil {
+		return err
+	}
+	client, closeCache, err := openScryfall(*cacheFile, *debug)
+	if err != nil {
+		return err
+	}
+	defer closeCache()
+
+	var opts []mtg.ResolverOption
+	if *printings {
+		opts = append(opts, mtg.ResolvePrintings())
+	}
+	resolved, rs := mtg.NewResolver(client, opts...).Resolve(deck)
+	if err := mtg.WriteResolutions(os.Stdout, rs); err != nil {
+		return err
+	}
+	if !*write || fileName == "-" {
+		return nil
+	}
+	tmp := fileName + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := codec.Encode(out, resolved); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, fileName)
+}
+
+// rewritable returns the codec of a deck file that can be rewritten without
+// losing lines: all lines must be parsed and plain text decks must not have
+// comments on lines of their own.
+func rewritable(fileName string, data []byte) (mtg.Codec, error) {
+	codec := mtg.DetectCodec(fileName, data)
+	if _, err := codec.Decode(bytes.NewReader(data)); err != nil {
+		if errs, ok := err.(mtg.ParseErrors); ok {
+			return codec, fmt.Errorf("%s: not rewriting a deck with lines that cannot be parsed: %v", fileName, errs)
+		}
+		return codec, err
+	}
+	if codec.Name != mtg.TextCodec {
+		return codec, nil
+	}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		_, header := mtg.SectionName(strings.TrimPrefix(line, "//"))
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") && !header {
+			return codec, fmt.Errorf("%s: not rewriting a deck with comments on lines of their own (line %d)", fileName, i+1)
+		}
+	}
+	return codec, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cognicraft/mtg"
+	"github.com/cognicraft/mtg/stats"
+)
+
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "Write the statistics as JSON")
+	cacheFile := fs.String("cache", "cache.arc", "Cache")
+	debug := fs.Bool("debug", false, "Debug?")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: deck stats [flags] <deck>\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	deck, err := readDeck(fs.Arg(0), "")
+	if err != nil {
+		return err
+	}
+	client, closeCache, err := openScryfall(*cacheFile, *debug)
+	if err != nil {
+		return err
+	}
+	defer closeCache()
+
+	resolved, rs := mtg.NewResolver(client).Resolve(deck)
+	for _, r := range rs {
+		if r.Status == mtg.NotFound || r.Status == mtg.Ambiguous {
+			fmt.Fprintf(os.Stderr, "WARNING: %s\n", r)
+		}
+	}
+	s := stats.Compute(resolved)
+	if *asJSON {
+		return stats.WriteJSON(os.Stdout, s)
+	}
+	return stats.WriteText(os.Stdout, s)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cognicraft/mtg"
+)
+
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	format := fs.String("format", "", "Format to validate the deck against")
+	cacheFile := fs.String("cache", "cache.arc", "Cache")
+	debug := fs.Bool("debug", false, "Debug?")
+	fs.Usage = func() {
+		var names []string
+		for _, f := range mtg.Formats {
+			names = append(names, f.Name)
+		}
+		fmt.Fprintf(os.Stderr, "usage: deck validate -format <format> [flags] <deck>\n\nformats: %s\n\nflags:\n", strings.Join(names, ", "))
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 || *format == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	f, ok := mtg.FormatByName(*format)
+	if !ok {
+		return fmt.Errorf("unknown format: %s", *format)
+	}
+
+	deck, err := readDeck(fs.Arg(0), "")
+	if err != nil {
+		return err
+	}
+	client, closeCache, err := openScryfall(*cacheFile, *debug)
+	if err != nil {
+		return err
+	}
+	defer closeCache()
+
+	vs := mtg.ValidateDeck(mtg.NewResolver(client), deck, f)
+	for _, v := range vs {
+		fmt.Println(v)
+	}
+	if len(vs) > 0 {
+		closeCache()
+		os.Exit(1)
+	}
+	fmt.Printf("valid %s deck\n", f.Name)
+	return nil
+}
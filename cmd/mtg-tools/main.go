@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"html"
 	"log"
 	"net/http"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/cognicraft/hyper"
 	"github.com/cognicraft/mtg"
 	"github.com/cognicraft/mtg/scryfall"
+	"github.com/cognicraft/mtg/stats"
 	"github.com/cognicraft/mux"
 )
 
@@ -34,9 +36,15 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	// refetches card data daily to keep the prices current
+	prices, err := scryfall.New(append(scOpts, scryfall.MaxAge(24*time.Hour))...)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	service := &Service{
 		Scryfall: scry,
+		Prices:   prices,
 	}
 
 	chain := mux.NewChain()
@@ -59,6 +67,7 @@ func main() {
 
 type Service struct {
 	Scryfall *scryfall.Client
+	Prices   *scryfall.Client
 }
 
 func (s *Service) handleGETStyleCSS(w http.ResponseWriter, r *http.Request) {
@@ -70,7 +79,11 @@ func (s *Service) handleGETStyleCSS(w http.ResponseWriter, r *http.Request) {
 func (s *Service) handleGET(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set(hyper.HeaderContentType, "text/html")
 	w.WriteHeader(http.StatusOK)
-	fmt.Fprint(w, index)
+	var formats strings.Builder
+	for _, f := range mtg.Formats {
+		fmt.Fprintf(&formats, "\t\t\t\t\t\t<option value=\"%s\">%s</option>\n", f.Name, f.Name)
+	}
+	fmt.Fprint(w, strings.Replace(index, "{{formats}}", formats.String(), 1))
 }
 
 func (s *Service) handlePOST(w http.ResponseWriter, r *http.Request) {
@@ -84,17 +97,30 @@ func (s *Service) handlePOST(w http.ResponseWriter, r *http.Request) {
 		name := cmd.Arguments.String("name")
 		tokens := cmd.Arguments.String("tokens")
 		numberOfTokens := cmd.Arguments.Int("number-of-tokens")
-		deckText := cmd.Arguments.String("deck")
-		deck, err := mtg.ParseDeck(strings.NewReader(deckText))
-		if err != nil {
-			hyper.Write(w, http.StatusBadRequest, hyper.Item{})
+		deck, ok := s.readDeck(w, cmd)
+		if !ok {
 			return
 		}
 		deck.Name = name
 
+		layout := mtg.DefaultPageLayout
+		if page := cmd.Arguments.String("page"); page != "" {
+			layout.Size = page
+		}
+		layout.Landscape = !cmd.Arguments.Bool("portrait")
+
 		opts := []mtg.PrinterOption{
 			mtg.Language(scryfall.Lang(lang)),
 			mtg.NumberOfTokens(numberOfTokens),
+			mtg.Layout(layout),
+		}
+		if duplex := cmd.Arguments.String("duplex"); duplex != "" {
+			flip, err := mtg.ParseFlip(duplex)
+			if err != nil {
+				writeMessages(w, http.StatusBadRequest, "Invalid duplex edge", []string{err.Error()})
+				return
+			}
+			opts = append(opts, mtg.Duplex(flip))
 		}
 		switch tokens {
 		case "only":
@@ -104,11 +130,119 @@ func (s *Service) handlePOST(w http.ResponseWriter, r *http.Request) {
 		}
 
 		w.Header().Set(hyper.HeaderContentType, "application/pdf")
-		err = mtg.NewProxyPrinter(s.Scryfall, deck, opts...).WriteImageProxies(w)
+		err := mtg.NewProxyPrinter(s.Scryfall, deck, opts...).WriteImageProxies(w)
 		if err != nil {
 			log.Printf("%#v", err)
 		}
+	case "validate-deck":
+		f, ok := mtg.FormatByName(cmd.Arguments.String("format"))
+		if !ok {
+			writeMessages(w, http.StatusBadRequest, "Unknown format", nil)
+			return
+		}
+		deck, ok := s.readDeck(w, cmd)
+		if !ok {
+			return
+		}
+		var messages []string
+		for _, v := range mtg.ValidateDeck(mtg.NewResolver(s.Scryfall), deck, f) {
+			messages = append(messages, v.String())
+		}
+		if len(messages) == 0 {
+			writeMessages(w, http.StatusOK, fmt.Sprintf("The deck is a valid %s deck", f.Name), nil)
+			return
+		}
+		writeMessages(w, http.StatusOK, fmt.Sprintf("The deck is not a valid %s deck", f.Name), messages)
+	case "deck-statistics":
+		deck, ok := s.readDeck(w, cmd)
+		if !ok {
+			return
+		}
+		resolved, _ := mtg.NewResolver(s.Scryfall).Resolve(deck)
+		st := stats.Compute(resolved)
+		if strings.Contains(r.Header.Get(hyper.HeaderAccept), hyper.ContentTypeJSON) {
+			w.Header().Set(hyper.HeaderContentType, hyper.ContentTypeJSON)
+			w.WriteHeader(http.StatusOK)
+			stats.WriteJSON(w, st)
+			return
+		}
+		var text strings.Builder
+		stats.WriteText(&text, st)
+		writeText(w, http.StatusOK, "Deck Statistics", text.String())
+	case "deck-prices":
+		currency := mtg.Currency(cmd.Arguments.String("currency"))
+		switch currency {
+		case "":
+			currency = mtg.USD
+		case mtg.USD, mtg.EUR, mtg.TIX:
+		default:
+			writeMessages(w, http.StatusBadRequest, "Unknown currency", []string{string(currency)})
+			return
+		}
+		var opts []mtg.PriceOption
+		if cmd.Arguments.Bool("cheapest") {
+			opts = append(opts, mtg.Cheapest())
+		}
+		deck, ok := s.readDeck(w, cmd)
+		if !ok {
+			return
+		}
+		report, err := mtg.PriceDeck(s.Prices, deck, currency, opts...)
+		if err != nil {
+			log.Printf("%v", err)
+		}
+		var text strings.Builder
+		mtg.WritePriceReport(&text, report)
+		writeText(w, http.StatusOK, "Deck Prices", text.String())
+	}
+}
+
+// readDeck reads the deck of the command in any supported format. If the deck
+// cannot be read an error page is written.
+func (s *Service) readDeck(w http.ResponseWriter, cmd hyper.Command) (mtg.Deck, bool) {
+	deckText := cmd.Arguments.String("deck")
+	skipInvalid := cmd.Arguments.Bool("skip-invalid")
+	deck, err := mtg.ReadDeck("", strings.NewReader(deckText))
+	if errs, ok := err.(mtg.ParseErrors); ok {
+		if !skipInvalid {
+			var messages []string
+			for _, e := range errs {
+				messages = append(messages, e.Error())
+			}
+			writeMessages(w, http.StatusBadRequest, "Some lines of the deck could not be parsed", messages,
+				`Go <a href="javascript:history.back()">back</a> to fix the deck, or check "Skip lines that cannot be parsed" to ignore them.`)
+			return mtg.Deck{}, false
+		}
+	} else if err != nil {
+		hyper.Write(w, http.StatusBadRequest, hyper.Item{})
+		return mtg.Deck{}, false
+	}
+	deck, err = mtg.ResolveMtgoIDs(s.Scryfall, deck)
+	if err != nil {
+		log.Printf("%v", err)
+	}
+	return deck, true
+}
+
+// writeMessages writes a page listing the messages. The optional hint is written as HTML.
+func writeMessages(w http.ResponseWriter, status int, title string, messages []string, hint ...string) {
+	w.Header().Set(hyper.HeaderContentType, "text/html")
+	w.WriteHeader(status)
+	var items strings.Builder
+	for _, m := range messages {
+		fmt.Fprintf(&items, "\t\t\t\t<li>%s</li>\n", html.EscapeString(m))
+	}
+	if len(hint) == 0 {
+		hint = append(hint, `Go <a href="javascript:history.back()">back</a> to the deck.`)
 	}
+	fmt.Fprintf(w, messagesPage, html.EscapeString(title), items.String(), strings.Join(hint, " "))
+}
+
+// writeText writes a page showing preformatted text.
+func writeText(w http.ResponseWriter, status int, title string, text string) {
+	w.Header().Set(hyper.HeaderContentType, "text/html")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, textPage, html.EscapeString(title), html.EscapeString(text))
 }
 
 const css = `
@@ -169,6 +303,11 @@ body {
 	margin-bottom: .5em;
 }
 
+.card pre {
+	margin: .75em 1em;
+	font-size: 12px;
+}
+
 fieldset {
 	padding: .75em;
 	margin-top: .5em;
@@ -213,7 +352,8 @@ input[type=reset] {
 	text-transform: uppercase;
 }
 
-input[type=submit] {
+input[type=submit], button[type=submit] {
+	margin-left: .5em;
 	padding: .5em;
 	color: #fff;
 	background-color: #009688;
@@ -244,11 +384,14 @@ const index = `
 		</div>
 		<div class="content">
 			<form action="/" method="POST">
-				<input type="hidden" name="@action" value="generate-proxies">
 				<fieldset>
 					<legend>Deck</legend>
 					<textarea name="deck" cols="80" rows="20"></textarea>
 				</fieldset>
+				<fieldset>
+					<input type="checkbox" id="skip-invalid" name="skip-invalid" value="true">
+					<label for="skip-invalid">Skip lines that cannot be parsed</label>
+				</fieldset>
 				<fieldset>
 					<legend>Language</legend>
 					<input type="radio" id="lang-en" name="lang" value="en" checked>
@@ -256,6 +399,22 @@ const index = `
 					<input type="radio" id="lang-de" name="lang" value="de">
 					<label for="lang-de">German</label>
 				</fieldset>
+				<fieldset>
+					<legend>Page</legend>
+					<select id="page" name="page">
+						<option value="A4">A4</option>
+						<option value="A3">A3</option>
+						<option value="Letter">Letter</option>
+						<option value="Legal">Legal</option>
+					</select>
+					<input type="checkbox" id="portrait" name="portrait" value="true">
+					<label for="portrait">Portrait</label>
+					<select id="duplex" name="duplex">
+						<option value="">Single-sided</option>
+						<option value="long">Duplex, flip on long edge</option>
+						<option value="short">Duplex, flip on short edge</option>
+					</select>
+				</fieldset>
 				<fieldset>
 					<legend>Do you need Tokens?</legend>
 					<input type="radio" id="no-tokens" name="tokens" value="none" checked>
@@ -272,9 +431,27 @@ const index = `
 					<label for="name">Name</label>
 					<input type="text" id="name" name="name" />
 				</fieldset>
+				<fieldset>
+					<legend>Format</legend>
+					<select id="format" name="format">
+{{formats}}					</select>
+				</fieldset>
+				<fieldset>
+					<legend>Prices</legend>
+					<select id="currency" name="currency">
+						<option value="usd">USD</option>
+						<option value="eur">EUR</option>
+						<option value="tix">TIX</option>
+					</select>
+					<input type="checkbox" id="cheapest" name="cheapest" value="true">
+					<label for="cheapest">Cheapest printings</label>
+				</fieldset>
 				<div class="buttons">
 					<input type="reset"/>
-					<input type="submit" value="Generate Proxies" />
+					<button type="submit" name="@action" value="generate-proxies">Generate Proxies</button>
+					<button type="submit" name="@action" value="validate-deck">Validate Deck</button>
+					<button type="submit" name="@action" value="deck-statistics">Statistics</button>
+					<button type="submit" name="@action" value="deck-prices">Prices</button>
 				</div>
 			</form>
 		</div>
@@ -304,3 +481,50 @@ const index = `
 </body>
 </html>
 `
+
+const messagesPage = `
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>MTG - Proxy Deck Generator</title>
+	<link rel="stylesheet" href="/css/style.css">
+</head>
+
+<body translate="no">
+	<div class="card">
+		<div class="header">
+			<h1>%s</h1>
+		</div>
+		<div class="content">
+			<ul>
+%s			</ul>
+			<p>%s</p>
+		</div>
+	</div>
+</body>
+</html>
+`
+
+const textPage = `
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>MTG - Proxy Deck Generator</title>
+	<link rel="stylesheet" href="/css/style.css">
+</head>
+
+<body translate="no">
+	<div class="card">
+		<div class="header">
+			<h1>%s</h1>
+		</div>
+		<div class="content">
+			<pre>%s</pre>
+			<p>Go <a href="javascript:history.back()">back</a> to the deck.</p>
+		</div>
+	</div>
+</body>
+</html>
+`
@@ -3,9 +3,11 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/cognicraft/archive"
 	"github.com/cognicraft/mtg"
@@ -21,6 +23,22 @@ func main() {
 	withTokens := flag.Bool("with-tokens", false, "With tokens?")
 	onlyTokens := flag.Bool("only-tokens", false, "Print only associated tokens")
 	numberOfTokens := flag.Int("number-of-tokens", 4, "The number of each token to print.")
+	sections := flag.String("sections", "", "Comma separated list of deck sections to print (default: all but the maybeboard)")
+	strict := flag.Bool("strict", false, "Fail on lines of the deck that cannot be parsed")
+	collection := flag.String("collection", "", "Collection file, print only the cards that are not owned and their tokens")
+	page := flag.String("page", "A4", "Page size: A3, A4, A5, Letter, Legal or Tabloid")
+	portrait := flag.Bool("portrait", false, "Portrait orientation")
+	margin := flag.Float64("margin", 10, "Minimum margin of the page in mm")
+	gap := flag.Float64("gap", 0, "Gap between cards in mm")
+	duplex := flag.String("duplex", "", "Print backs for a duplex printer that flips on the long or short edge")
+	backOffsetX := flag.Float64("back-offset-x", 0, "Horizontal offset of the backs in mm")
+	backOffsetY := flag.Float64("back-offset-y", 0, "Vertical offset of the backs in mm")
+	bleed := flag.Float64("bleed", 0, "Extend the card images by mirroring their edges, in mm")
+	cutLines := flag.Bool("cut-lines", false, "Draw cut lines across the page")
+	cutOutlines := flag.Bool("cut-outlines", false, "Draw the outlines of the cards with rounded corners")
+	registration := flag.String("registration", "", "Registration marks for a cutting machine: silhouette or cricut")
+	back := flag.String("back", "", "Image to print on the backs of the cards")
+	nameBack := flag.Bool("name-back", false, "Print the name of the deck on the backs of the cards")
 	debug := flag.Bool("debug", false, "Debug?")
 	v := flag.Bool("version", false, "Version")
 	flag.Parse()
@@ -33,6 +51,12 @@ func main() {
 	if len(flag.Args()) != 1 {
 		log.Fatal(fmt.Errorf("no deck specified"))
 	}
+	if *back != "" && *nameBack {
+		log.Fatal(fmt.Errorf("-back and -name-back cannot be combined"))
+	}
+	if *nameBack && *n == "" {
+		log.Fatal(fmt.Errorf("-name-back needs a -name"))
+	}
 
 	cache, err := archive.Open(*c)
 	if err != nil {
@@ -47,15 +71,6 @@ func main() {
 	}
 	defer deckFile.Close()
 
-	deck, err := mtg.ParseDeck(deckFile)
-	if err != nil {
-		log.Fatal(err)
-	}
-	deck.Name = *n
-
-	ext := filepath.Ext(deckFileName)
-	proxyFileName := deckFileName[0:len(deckFileName)-len(ext)] + ".pdf"
-
 	var scOpts []scryfall.ClientOption
 	scOpts = append(scOpts, scryfall.Cache(cache))
 	if *debug {
@@ -67,14 +82,68 @@ func main() {
 		log.Fatal(err)
 	}
 
+	var parseOpts []mtg.ParseOption
+	if *strict {
+		parseOpts = append(parseOpts, mtg.Strict())
+	}
+	deck, err := mtg.ReadDeck(deckFileName, deckFile, parseOpts...)
+	check(deckFileName, err)
+	deck, err = mtg.ResolveMtgoIDs(scry, deck)
+	check(deckFileName, err)
+	if *collection != "" {
+		c, err := mtg.ReadCollectionFile(*collection)
+		check(*collection, err)
+		_, deck = c.Subtract(deck)
+	}
+	deck.Name = *n
+
+	ext := filepath.Ext(deckFileName)
+	proxyFileName := deckFileName[0:len(deckFileName)-len(ext)] + ".pdf"
+
 	var opts []mtg.PrinterOption
 	opts = append(opts, mtg.NumberOfTokens(*numberOfTokens))
+	opts = append(opts, mtg.Layout(mtg.PageLayout{Size: *page, Landscape: !*portrait, Margin: *margin, Gap: *gap}))
+	if *back != "" {
+		data, err := ioutil.ReadFile(*back)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, mtg.CardBack(data))
+	}
+	if *nameBack {
+		opts = append(opts, mtg.DeckNameBack())
+	}
+	if *duplex != "" {
+		flip, err := mtg.ParseFlip(*duplex)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, mtg.Duplex(flip))
+	}
+	if *backOffsetX != 0 || *backOffsetY != 0 {
+		opts = append(opts, mtg.BackOffset(*backOffsetX, *backOffsetY))
+	}
+	if *bleed > 0 {
+		opts = append(opts, mtg.Bleed(*bleed))
+	}
+	if *cutLines {
+		opts = append(opts, mtg.CutLines())
+	}
+	if *cutOutlines {
+		opts = append(opts, mtg.CutOutlines())
+	}
+	if *registration != "" {
+		opts = append(opts, mtg.RegistrationMarks(mtg.CuttingMachine(*registration)))
+	}
 	if *withTokens {
 		opts = append(opts, mtg.PrintTokens())
 	}
 	if *onlyTokens {
 		opts = append(opts, mtg.PrintOnlyTokens())
 	}
+	if *sections != "" {
+		opts = append(opts, mtg.Sections(strings.Split(*sections, ",")...))
+	}
 
 	switch *f {
 	case "text":
@@ -86,3 +155,18 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// check reports dropped or unresolvable cards as warnings and exits on any other error.
+func check(fileName string, err error) {
+	switch err := err.(type) {
+	case nil:
+	case mtg.ParseErrors:
+		for _, e := range err {
+			fmt.Fprintf(os.Stderr, "WARNING: %s: dropped %v\n", fileName, e)
+		}
+	case *mtg.NotFoundError:
+		fmt.Fprintf(os.Stderr, "WARNING: %s: %v\n", fileName, err)
+	default:
+		log.Fatal(err)
+	}
+}
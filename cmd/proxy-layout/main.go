@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"strings"
@@ -16,6 +17,19 @@ func main() {
 	n := flag.String("name", "", "Name")
 	playset := flag.Bool("playset", false, "Playset?")
 	no := flag.Int("copies", 1, "Number of copies per card.")
+	page := flag.String("page", "A4", "Page size: A3, A4, A5, Letter, Legal or Tabloid")
+	portrait := flag.Bool("portrait", false, "Portrait orientation")
+	margin := flag.Float64("margin", 10, "Minimum margin of the page in mm")
+	gap := flag.Float64("gap", 0, "Gap between cards in mm")
+	duplex := flag.String("duplex", "", "Print backs for a duplex printer that flips on the long or short edge")
+	backOffsetX := flag.Float64("back-offset-x", 0, "Horizontal offset of the backs in mm")
+	backOffsetY := flag.Float64("back-offset-y", 0, "Vertical offset of the backs in mm")
+	bleed := flag.Float64("bleed", 0, "Extend the card images by mirroring their edges, in mm")
+	cutLines := flag.Bool("cut-lines", false, "Draw cut lines across the page")
+	cutOutlines := flag.Bool("cut-outlines", false, "Draw the outlines of the cards with rounded corners")
+	registration := flag.String("registration", "", "Registration marks for a cutting machine: silhouette or cricut")
+	back := flag.String("back", "", "Image to print on the backs of the cards")
+	nameBack := flag.Bool("name-back", false, "Print the name of the deck on the backs of the cards")
 	v := flag.Bool("version", false, "Version")
 	flag.Parse()
 
@@ -27,6 +41,12 @@ func main() {
 	if len(flag.Args()) != 1 {
 		log.Fatal(fmt.Errorf("no folder specified"))
 	}
+	if *back != "" && *nameBack {
+		log.Fatal(fmt.Errorf("-back and -name-back cannot be combined"))
+	}
+	if *nameBack && *n == "" {
+		log.Fatal(fmt.Errorf("-name-back needs a -name"))
+	}
 
 	dirName := flag.Arg(0)
 	outFileName := dirName + ".pdf"
@@ -42,7 +62,41 @@ func main() {
 		numberOfCopies = *no
 	}
 
-	err := mtg.LayoutDirectory(*n, numberOfCopies, dirName, outFileName)
+	layout := mtg.PageLayout{Size: *page, Landscape: !*portrait, Margin: *margin, Gap: *gap}
+	opts := []mtg.PrinterOption{mtg.Layout(layout)}
+	if *back != "" {
+		data, err := ioutil.ReadFile(*back)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, mtg.CardBack(data))
+	}
+	if *nameBack {
+		opts = append(opts, mtg.DeckNameBack())
+	}
+	if *duplex != "" {
+		flip, err := mtg.ParseFlip(*duplex)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, mtg.Duplex(flip))
+	}
+	if *backOffsetX != 0 || *backOffsetY != 0 {
+		opts = append(opts, mtg.BackOffset(*backOffsetX, *backOffsetY))
+	}
+	if *bleed > 0 {
+		opts = append(opts, mtg.Bleed(*bleed))
+	}
+	if *cutLines {
+		opts = append(opts, mtg.CutLines())
+	}
+	if *cutOutlines {
+		opts = append(opts, mtg.CutOutlines())
+	}
+	if *registration != "" {
+		opts = append(opts, mtg.RegistrationMarks(mtg.CuttingMachine(*registration)))
+	}
+	err := mtg.LayoutDirectory(*n, numberOfCopies, dirName, outFileName, opts...)
 	if err != nil {
 		log.Fatal(err)
 	}
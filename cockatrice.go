@@ -0,0 +1,132 @@
+package mtg
+
+import (
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+type cockatriceDeck struct {
+	XMLName  xml.Name         `xml:"cockatrice_deck"`
+	Version  string           `xml:"version,attr"`
+	DeckName string           `xml:"deckname"`
+	Comments string           `xml:"comments"`
+	Zones    []cockatriceZone `xml:"zone"`
+}
+
+type cockatriceZone struct {
+	Name  string           `xml:"name,attr"`
+	Cards []cockatriceCard `xml:"card"`
+}
+
+type cockatriceCard struct {
+	Number          int    `xml:"number,attr"`
+	Name            string `xml:"name,attr"`
+	SetShortName    string `xml:"setShortName,attr,omitempty"`
+	CollectorNumber string `xml:"collectorNumber,attr,omitempty"`
+}
+
+var cockatriceZones = map[string]string{
+	"main": Main,
+	"side": Sideboard,
+}
+
+// ParseCockatriceDeck parses a Cockatrice .cod file.
+func ParseCockatriceDeck(in io.Reader, opts ...ParseOption) (Deck, error) {
+	p := &deckParser{}
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return Deck{}, err
+		}
+	}
+
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return Deck{}, err
+	}
+	cd := cockatriceDeck{}
+	if err := xml.Unmarshal(data, &cd); err != nil {
+		return Deck{}, err
+	}
+	lines, err := xmlElementLines(data, "cockatrice_deck", "zone", "card")
+	if err != nil {
+		return Deck{}, err
+	}
+	deck := Deck{Name: cd.DeckName}
+	var errs ParseErrors
+	n := 0
+	for _, z := range cd.Zones {
+		section, ok := cockatriceZones[z.Name]
+		if !ok {
+			section = z.Name
+			if name, ok := SectionName(z.Name); ok {
+				section = name
+			}
+		}
+		for _, cc := range z.Cards {
+			line := lines[n]
+			n++
+			if cc.Number < 1 || cc.Name == "" {
+				err := &ParseError{Line: line, Text: cc.Name, Reason: "missing number or name"}
+				if p.strict {
+					return Deck{}, err
+				}
+				errs = append(errs, err)
+				continue
+			}
+			card := Card{Name: cc.Name}
+			if cc.SetShortName != "" {
+				card.Version = &Version{Set: cc.SetShortName, CollectorNumber: cc.CollectorNumber}
+			}
+			s := deck.Section(section)
+			s.Entries = append(s.Entries, Entry{Count: cc.Number, Card: card, Line: line})
+		}
+	}
+	if len(deck.Sections) == 0 {
+		deck.Sections = append(deck.Sections, Section{Name: Main})
+	}
+	if len(errs) > 0 {
+		return deck, errs
+	}
+	return deck, nil
+}
+
+// WriteCockatriceDeck writes the deck as a Cockatrice .cod file. All sections but
+// the main deck and the maybeboard end up in the sideboard.
+func WriteCockatriceDeck(w io.Writer, d Deck) error {
+	cd := cockatriceDeck{Version: "1", DeckName: d.Name}
+	main := cockatriceZone{Name: "main"}
+	side := cockatriceZone{Name: "side"}
+	for _, s := range d.Sections {
+		if s.Name == Maybeboard {
+			continue
+		}
+		for _, e := range s.Entries {
+			cc := cockatriceCard{Number: e.Count, Name: e.Card.Name}
+			if v := e.Card.Version; v != nil {
+				cc.SetShortName = strings.ToUpper(v.Set)
+				cc.CollectorNumber = v.CollectorNumber
+			}
+			if s.Name == Main {
+				main.Cards = append(main.Cards, cc)
+			} else {
+				side.Cards = append(side.Cards, cc)
+			}
+		}
+	}
+	cd.Zones = append(cd.Zones, main)
+	if len(side.Cards) > 0 {
+		cd.Zones = append(cd.Zones, side)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "    ")
+	if err := enc.Encode(cd); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
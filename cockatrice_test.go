@@ -0,0 +1,76 @@
+package mtg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseCockatriceDeck(t *testing.T) {
+	raw := `<?xml version="1.0" encoding="UTF-8"?>
+<cockatrice_deck version="1">
+    <deckname>Burn</deckname>
+    <comments></comments>
+    <zone name="main">
+        <card number="4" name="Lightning Bolt" setShortName="M11" collectorNumber="146"></card>
+        <card number="20" name="Mountain"></card>
+    </zone>
+    <zone name="side">
+        <card number="2" name="Pyroblast"></card>
+    </zone>
+</cockatrice_deck>
+`
+
+	d, err := ParseCockatriceDeck(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Name != "Burn" {
+		t.Errorf("want: %q, got: %q", "Burn", d.Name)
+	}
+	if n := d.Section(Main).Count(); n != 24 {
+		t.Errorf("want: %d, got: %d", 24, n)
+	}
+	if n := d.Section(Sideboard).Count(); n != 2 {
+		t.Errorf("want: %d, got: %d", 2, n)
+	}
+	want := Version{Set: "M11", CollectorNumber: "146"}
+	if v := d.Section(Main).Entries[0].Card.Version; v == nil || *v != want {
+		t.Errorf("want: %v, got: %v", want, v)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteCockatriceDeck(buf, d); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != raw {
+		t.Errorf("want:\n%s\ngot:\n%s", raw, got)
+	}
+}
+
+func TestParseCockatriceDeckLines(t *testing.T) {
+	raw := `<?xml version="1.0" encoding="UTF-8"?>
+<cockatrice_deck version="1">
+    <extra><card number="1" name="Shock"></card></extra>
+    <zone name="main">
+        <card number="4" name="Lightning Bolt"></card>
+        <card number="0" name="Mountain"></card>
+    </zone>
+    <zone name="side">
+
+        <card number="2" name="Pyroblast"></card>
+    </zone>
+</cockatrice_deck>
+`
+	d, err := ParseCockatriceDeck(strings.NewReader(raw))
+	errs, ok := err.(ParseErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("want: 1 parse error, got: %v", err)
+	}
+	if want, got := 6, errs[0].Line; want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	if want, got := 10, d.Section(Sideboard).Entries[0].Line; want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
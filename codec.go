@@ -0,0 +1,181 @@
+package mtg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// A Codec reads and writes decks in a specific file format.
+type Codec struct {
+	Name       string
+	Extensions []string
+	Decode     func(io.Reader, ...ParseOption) (Deck, error)
+	Encode     func(io.Writer, Deck) error
+	// Sniff reports whether the beginning of a file looks like this format.
+	Sniff func([]byte) bool
+}
+
+const TextCodec = "text"
+
+var codecs = []Codec{
+	{
+		Name:       "mtgo",
+		Extensions: []string{".dek"},
+		Decode:     ParseMTGODeck,
+		Encode:     WriteMTGODeck,
+		Sniff:      sniffPattern(`^\s*(<\?xml[^>]*>\s*)?<Deck[\s>]`),
+	},
+	{
+		Name:       "cockatrice",
+		Extensions: []string{".cod"},
+		Decode:     ParseCockatriceDeck,
+		Encode:     WriteCockatriceDeck,
+		Sniff:      sniffPattern(`^\s*(<\?xml[^>]*>\s*)?<cockatrice_deck[\s>]`),
+	},
+	{
+		Name:       "forge",
+		Extensions: []string{".dck"},
+		Decode:     ParseForgeDeck,
+		Encode:     WriteForgeDeck,
+		Sniff:      sniffPattern(`(?mi)^\[(metadata|main)\]\s*$`),
+	},
+	{
+		Name:       "arena",
+		Extensions: []string{".txt"},
+		Decode:     ParseArenaDeck,
+		Encode:     WriteArenaDeck,
+		Sniff:      sniffArena,
+	},
+	{
+		Name:       "csv",
+		Extensions: []string{".csv"},
+		Decode:     ParseCSVDeck,
+		Encode:     WriteCSVDeck,
+		Sniff:      sniffCSV,
+	},
+	{
+		Name:   "moxfield",
+		Decode: CSVCodec{Columns: MoxfieldCSVColumns}.Decode,
+		Encode: CSVCodec{Columns: MoxfieldCSVColumns}.Encode,
+	},
+	{
+		Name:   "deckbox",
+		Decode: CSVCodec{Columns: DeckboxCSVColumns}.Decode,
+		Encode: CSVCodec{Columns: DeckboxCSVColumns}.Encode,
+	},
+	{
+		Name:   "manabox",
+		Decode: CSVCodec{Columns: ManaBoxCSVColumns}.Decode,
+		Encode: CSVCodec{Columns: ManaBoxCSVColumns}.Encode,
+	},
+	{
+		Name:       TextCodec,
+		Extensions: []string{".txt"},
+		Decode:     ParseDeck,
+		Encode:     WriteDeck,
+	},
+}
+
+func sniffPattern(pattern string) func([]byte) bool {
+	re := regexp.MustCompile(pattern)
+	return re.Match
+}
+
+// RegisterCodec makes an additional deck format available. Codecs registered
+// later take precedence when sniffing.
+func RegisterCodec(c Codec) {
+	codecs = append([]Codec{c}, codecs...)
+}
+
+func Codecs() []Codec {
+	return append([]Codec(nil), codecs...)
+}
+
+func CodecByName(name string) (Codec, bool) {
+	for _, c := range codecs {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+	return Codec{}, false
+}
+
+// DetectCodec picks the codec for a file by its extension. If the extension is
+// unknown or ambiguous the content is sniffed, falling back to the plain text format.
+func DetectCodec(fileName string, head []byte) Codec {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	var candidates []Codec
+	for _, c := range codecs {
+		for _, e := range c.Extensions {
+			if e == ext {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	if len(candidates) == 0 {
+		candidates = codecs
+	}
+	for _, c := range candidates {
+		if c.Sniff != nil && c.Sniff(head) {
+			return c
+		}
+	}
+	c, _ := CodecByName(TextCodec)
+	return c
+}
+
+// ReadDeck decodes a deck in any of the registered formats.
+func ReadDeck(fileName string, in io.Reader, opts ...ParseOption) (Deck, error) {
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return Deck{}, err
+	}
+	c := DetectCodec(fileName, data)
+	return c.Decode(bytes.NewReader(data), opts...)
+}
+
+func ReadDeckFile(fileName string, opts ...ParseOption) (Deck, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return Deck{}, err
+	}
+	defer f.Close()
+	return ReadDeck(fileName, f, opts...)
+}
+
+// xmlElementLines returns the lines on which the elements at the path of
+// element names start, in the order of the document, e.g. the lines of the
+// cards of a Cockatrice deck at cockatrice_deck, zone, card.
+func xmlElementLines(data []byte, path ...string) ([]int, error) {
+	var lines []int
+	var stack []string
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		offset := dec.InputOffset()
+		t, err := dec.Token()
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			if strings.Join(stack, "/") == strings.Join(path, "/") {
+				lines = append(lines, 1+bytes.Count(data[:offset], []byte("\n")))
+			}
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
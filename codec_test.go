@@ -0,0 +1,36 @@
+package mtg
+
+import (
+	"testing"
+)
+
+func TestDetectCodec(t *testing.T) {
+	tests := []struct {
+		fileName string
+		head     string
+		want     string
+	}{
+		{"deck.dek", "", "mtgo"},
+		{"deck.cod", "", "cockatrice"},
+		{"deck.dck", "", "forge"},
+		{"deck.txt", "4 Lightning Bolt\n", TextCodec},
+		{"deck.txt", "Deck\n4 Lightning Strike (M19) 152\n", "arena"},
+		{"deck.txt", "1 [DOM:205] Slimefoot, the Stowaway\n", TextCodec},
+		{"deck.txt", "4 Lightning Strike (M19) 152\n\n2 Shock (M19) 156\n", "arena"},
+		{"deck.txt", "About\nName Burn #1\n\nDeck\n4 Lightning Strike (M19) 152\n1 Mountain\n", "arena"},
+		{"deck.txt", "4 Lightning Bolt\n1 Llanowar Elves (DOM) 168\n", TextCodec},
+		{"deck.txt", "4 Lightning Bolt [M11]\n# burn\n4 Goblin Guide !Aggro\n1 Llanowar Elves (DOM) 168\n", TextCodec},
+		{"deck.txt", "Deck\n4 Lightning Strike (M19) 152\n4 Goblin Guide # !Aggro\n", TextCodec},
+		{"", "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<Deck xmlns:xsd=\"http://www.w3.org/2001/XMLSchema\">", "mtgo"},
+		{"", "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<cockatrice_deck version=\"1\">", "cockatrice"},
+		{"", "[metadata]\nName=Slimefoot\n[Main]\n4 Forest|DOM\n", "forge"},
+		{"deck.csv", "", "csv"},
+		{"", "Count,Name,Edition\n4,Lightning Bolt,m11\n", "csv"},
+		{"", "4 Lightning Bolt\n", TextCodec},
+	}
+	for _, test := range tests {
+		if got := DetectCodec(test.fileName, []byte(test.head)).Name; got != test.want {
+			t.Errorf("%q %q: want: %s, got: %s", test.fileName, test.head, test.want, got)
+		}
+	}
+}
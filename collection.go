@@ -0,0 +1,104 @@
+package mtg
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// A Collection is the cards a player owns. Each item is a card in a printing,
+// finish, language and condition with the number of owned copies as count.
+type Collection struct {
+	Items []Entry
+}
+
+// ReadCollection reads a collection from a file in any supported deck format,
+// e.g. a CSV export of a collection manager. The sections of the file are
+// ignored. Lines that cannot be parsed are reported as for ReadDeck.
+func ReadCollection(fileName string, in io.Reader, opts ...ParseOption) (Collection, error) {
+	d, err := ReadDeck(fileName, in, opts...)
+	if _, ok := err.(ParseErrors); err != nil && !ok {
+		return Collection{}, err
+	}
+	c := Collection{}
+	for _, s := range d.Sections {
+		c.Items = append(c.Items, s.Entries...)
+	}
+	return c, err
+}
+
+func ReadCollectionFile(fileName string, opts ...ParseOption) (Collection, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return Collection{}, err
+	}
+	defer f.Close()
+	return ReadCollection(fileName, f, opts...)
+}
+
+// Count returns the number of owned copies of a card in any printing.
+func (c Collection) Count(name string) int {
+	n := 0
+	for _, i := range c.Items {
+		if strings.EqualFold(i.Card.Name, name) {
+			n += i.Count
+		}
+	}
+	return n
+}
+
+// Subtract subtracts the collection from a deck. Cards are matched by name,
+// any printing of a card can be played. The shopping list holds the copies the
+// deck plays that are not owned.
+//
+// Decks that share the collection, as with the Staples Binder Method, can be
+// given as others. The originals of cards played by other decks too are kept
+// in the binder, so the proxy list holds every copy of those cards besides the
+// copies that are not owned. As the originals stay in the binder, the copies
+// played by other decks are not subtracted from the collection, the shopping
+// list holds only the copies this deck is missing in the binder.
+func (c Collection) Subtract(d Deck, others ...Deck) (Deck, Deck) {
+	shared := map[string]bool{}
+	for _, o := range others {
+		for _, s := range o.Sections {
+			if s.Name == Maybeboard {
+				continue
+			}
+			for _, e := range s.Entries {
+				shared[strings.ToLower(e.Card.Name)] = true
+			}
+		}
+	}
+
+	owned := map[string]int{}
+	buy := Deck{Name: d.Name}
+	proxy := Deck{Name: d.Name}
+	for _, s := range d.Sections {
+		if s.Name == Maybeboard {
+			continue
+		}
+		for _, e := range s.Entries {
+			key := strings.ToLower(e.Card.Name)
+			if _, ok := owned[key]; !ok {
+				owned[key] = c.Count(e.Card.Name)
+			}
+			covered := e.Count
+			if covered > owned[key] {
+				covered = owned[key]
+			}
+			owned[key] -= covered
+			if missing := e.Count - covered; missing > 0 {
+				be := e
+				be.Count = missing
+				buy.Section(s.Name).Entries = append(buy.Section(s.Name).Entries, be)
+				if !shared[key] {
+					proxy.Section(s.Name).Entries = append(proxy.Section(s.Name).Entries, be)
+				}
+			}
+			if shared[key] {
+				proxy.Section(s.Name).Entries = append(proxy.Section(s.Name).Entries, e)
+			}
+		}
+	}
+	return buy, proxy
+}
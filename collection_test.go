@@ -0,0 +1,64 @@
+package mtg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCollectionSubtract(t *testing.T) {
+	c, err := ReadCollection("collection.csv", strings.NewReader(`Count,Name,Edition,Collector Number,Foil
+2,Lightning Bolt,M11,146,foil
+1,Lightning Bolt,2XM,117,
+3,"Ragavan, Nimble Pilferer",MH2,138,
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := 3, c.Count("lightning bolt"); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	deck, err := ParseDeck(strings.NewReader(`4 Lightning Bolt
+4 Ragavan, Nimble Pilferer
+2 Mountain
+//Sideboard
+1 Lightning Bolt
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ParseDeck(strings.NewReader("4 Ragavan, Nimble Pilferer\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buy, proxy := c.Subtract(deck, other)
+	tests := []struct {
+		name string
+		deck Deck
+		want string
+	}{
+		{
+			name: "buy",
+			deck: buy,
+			want: "//Main\n1 Lightning Bolt\n1 Ragavan, Nimble Pilferer\n2 Mountain\n\n//Sideboard\n1 Lightning Bolt\n",
+		},
+		{
+			name: "proxy",
+			deck: proxy,
+			want: "//Main\n1 Lightning Bolt\n4 Ragavan, Nimble Pilferer\n2 Mountain\n\n//Sideboard\n1 Lightning Bolt\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if err := WriteDeck(buf, test.deck); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); test.want != got {
+				t.Errorf("want:\n%s\ngot:\n%s", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package mtg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cognicraft/mtg/scryfall"
+)
+
+// Commanders returns the entries of the Commander section. Decks without such
+// a section may mark their commanders with a !Commander tag instead.
+func (d Deck) Commanders() []Entry {
+	var es []Entry
+	for _, s := range d.Sections {
+		for _, e := range s.Entries {
+			if s.Name == Commander || e.HasTag(Commander) {
+				es = append(es, e)
+			}
+		}
+	}
+	return es
+}
+
+// ValidateCommanderDeck checks the deck against the rules of the commander format.
+func ValidateCommanderDeck(r *Resolver, d Deck) []Violation {
+	f, _ := FormatByName("commander")
+	return ValidateDeck(r, d, f)
+}
+
+// commanderViolations checks the commanders of a resolved deck and the color
+// identity of its cards.
+func commanderViolations(r *Resolver, d Deck, f Format) []Violation {
+	type commander struct {
+		Entry
+		sc *scryfall.Card
+	}
+	es := d.Commanders()
+	if len(es) == 0 {
+		return []Violation{{Section: Commander, Reason: "deck has no commander"}}
+	}
+	var cs []commander
+	for _, e := range es {
+		sc := r.Card(e.Card)
+		if sc == nil {
+			// the commander is reported with the entries without a card, without
+			// it there is no color identity to check against
+			return []Violation{{Section: Commander, Reason: "commanders cannot be checked"}}
+		}
+		cs = append(cs, commander{e, sc})
+	}
+
+	var vs []Violation
+	switch len(cs) {
+	case 1:
+		if !canBeCommander(cs[0].sc, f) {
+			vs = append(vs, Violation{Card: cs[0].Card.Name, Section: Commander, Reason: "cannot be a commander"})
+		}
+	case 2:
+		a, b := cs[0].sc, cs[1].sc
+		switch {
+		case canPartner(a, b):
+		case choosesBackground(a) && isBackground(b), choosesBackground(b) && isBackground(a):
+		default:
+			vs = append(vs, Violation{Section: Commander, Reason: fmt.Sprintf("%s and %s cannot be commanders together", a.Name, b.Name)})
+		}
+		for _, c := range cs {
+			if !canBeCommander(c.sc, f) && !isBackground(c.sc) {
+				vs = append(vs, Violation{Card: c.Card.Name, Section: Commander, Reason: "cannot be a commander"})
+			}
+		}
+	default:
+		vs = append(vs, Violation{Section: Commander, Reason: fmt.Sprintf("deck has %d commanders, at most 2 allowed", len(cs))})
+	}
+
+	identity := map[string]bool{}
+	for _, c := range cs {
+		for _, color := range c.sc.ColorIdentity {
+			identity[color] = true
+		}
+	}
+	seen := map[string]bool{}
+	for _, s := range d.Sections {
+		switch s.Name {
+		case Main, Commander, Companion:
+		default:
+			continue
+		}
+		for _, e := range s.Entries {
+			sc := r.Card(e.Card)
+			if sc == nil || seen[e.Card.Name] {
+				continue
+			}
+			seen[e.Card.Name] = true
+			for _, color := range sc.ColorIdentity {
+				if !identity[color] {
+					vs = append(vs, Violation{Card: e.Card.Name, Section: s.Name, Reason: fmt.Sprintf("color identity %s outside of the commander's %s", colors(sc.ColorIdentity), colors(keys(identity)))})
+					break
+				}
+			}
+		}
+	}
+	return vs
+}
+
+func canBeCommander(sc *scryfall.Card, f Format) bool {
+	t := frontTypeLine(sc)
+	if strings.HasPrefix(t, "Legendary") {
+		if strings.Contains(t, "Creature") {
+			return true
+		}
+		if strings.HasSuffix(f.Name, "brawl") && strings.Contains(t, "Planeswalker") {
+			return true
+		}
+	}
+	return strings.Contains(oracleText(sc), "can be your commander")
+}
+
+func canPartner(a, b *scryfall.Card) bool {
+	switch {
+	case hasAbility(a, "Partner") && hasAbility(b, "Partner"):
+		return true
+	case hasAbility(a, "Friends forever") && hasAbility(b, "Friends forever"):
+		return true
+	case hasAbility(a, "Partner with "+b.Name) && hasAbility(b, "Partner with "+a.Name):
+		return true
+	case hasAbility(a, "Doctor's companion") && isDoctor(b), hasAbility(b, "Doctor's companion") && isDoctor(a):
+		return true
+	}
+	return false
+}
+
+func choosesBackground(sc *scryfall.Card) bool {
+	return hasAbility(sc, "Choose a Background")
+}
+
+func isBackground(sc *scryfall.Card) bool {
+	t := frontTypeLine(sc)
+	return strings.HasPrefix(t, "Legendary") && strings.HasSuffix(t, "Background")
+}
+
+func isDoctor(sc *scryfall.Card) bool {
+	return strings.HasSuffix(frontTypeLine(sc), "Time Lord Doctor")
+}
+
+// hasAbility reports whether a line of the oracle text is the keyword ability,
+// optionally followed by reminder text.
+func hasAbility(sc *scryfall.Card, ability string) bool {
+	for _, l := range strings.Split(oracleText(sc), "\n") {
+		l = strings.TrimSpace(l)
+		if i := strings.Index(l, " ("); i >= 0 {
+			l = l[:i]
+		}
+		if strings.EqualFold(l, ability) {
+			return true
+		}
+	}
+	return false
+}
+
+func frontTypeLine(sc *scryfall.Card) string {
+	if f := sc.Front(); f != nil && f.TypeLine != "" {
+		return f.TypeLine
+	}
+	if i := strings.Index(sc.TypeLine, " // "); i >= 0 {
+		return sc.TypeLine[:i]
+	}
+	return sc.TypeLine
+}
+
+func keys(m map[string]bool) []string {
+	var ks []string
+	for k := range m {
+		ks = append(ks, k)
+	}
+	return ks
+}
+
+// colors formats a color identity in WUBRG order, e.g. {B}{G}.
+func colors(cs []string) string {
+	s := ""
+	for _, c := range []string{"W", "U", "B", "R", "G"} {
+		for _, o := range cs {
+			if o == c {
+				s += "{" + c + "}"
+				break
+			}
+		}
+	}
+	if s == "" {
+		return "{C}"
+	}
+	return s
+}
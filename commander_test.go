@@ -0,0 +1,106 @@
+package mtg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCommanderDeck(t *testing.T) {
+	client, done := newTestScryfall(t)
+	defer done()
+	r := NewResolver(client)
+
+	tests := []struct {
+		name string
+		deck string
+		want []string
+	}{
+		{
+			name: "valid",
+			deck: "//Commander\n1 Slimefoot, the Stowaway\n//Main\n98 Forest\n1 Sol Ring\n",
+		},
+		{
+			name: "tagged",
+			deck: "1 Slimefoot, the Stowaway # !Commander\n98 Forest\n1 Sol Ring\n",
+		},
+		{
+			name: "color identity",
+			deck: "//Commander\n1 Slimefoot, the Stowaway\n//Main\n97 Forest\n1 Sol Ring\n1 Lightning Bolt\n",
+			want: []string{
+				"Lightning Bolt: color identity {R} outside of the commander's {B}{G}",
+			},
+		},
+		{
+			name: "no commander",
+			deck: "99 Forest\n1 Sol Ring\n",
+			want: []string{
+				"deck has no commander",
+			},
+		},
+		{
+			name: "not legendary",
+			deck: "//Commander\n1 Lightning Bolt\n//Main\n98 Mountain\n1 Sol Ring\n2 Relentless Rats\n",
+			want: []string{
+				"deck has 102 cards, at most 100 allowed",
+				"Lightning Bolt: cannot be a commander",
+				"Relentless Rats: color identity {B} outside of the commander's {R}",
+			},
+		},
+		{
+			name: "unresolved commander",
+			deck: "//Commander\n1 Xyzzy\n//Main\n98 Forest\n1 Sol Ring\n",
+			want: []string{
+				"Xyzzy: not_found",
+				"commanders cannot be checked",
+			},
+		},
+		{
+			name: "reversible commander",
+			deck: "//Commander\n1 Zndrsplt, Eye of Wisdom\n//Main\n97 Forest\n1 Sol Ring\n1 Lightning Bolt\n",
+			want: []string{
+				"Forest: color identity {G} outside of the commander's {U}",
+				"Lightning Bolt: color identity {R} outside of the commander's {U}",
+			},
+		},
+		{
+			name: "partner without a card",
+			deck: "//Commander\n1 Zndrsplt, Eye of Wisdom\n1 Okaun, Eye of Chaos\n//Main\n97 Forest\n1 Lightning Bolt\n",
+			want: []string{
+				"Okaun, Eye of Chaos: cannot be checked",
+				"commanders cannot be checked",
+			},
+		},
+		{
+			name: "partners",
+			deck: "//Commander\n1 Thrasios, Triton Hero\n1 Tymna the Weaver\n//Main\n97 Forest\n1 Sol Ring\n",
+		},
+		{
+			name: "background",
+			deck: "//Commander\n1 Wilson, Refined Grizzly\n1 Raised by Giants\n//Main\n97 Forest\n1 Sol Ring\n",
+		},
+		{
+			name: "no partners",
+			deck: "//Commander\n1 Slimefoot, the Stowaway\n1 Wilson, Refined Grizzly\n//Main\n98 Forest\n1 Sol Ring\n1 Sol Ring\n",
+			want: []string{
+				"deck has 102 cards, at most 100 allowed",
+				"Sol Ring: 2 copies, at most 1 allowed",
+				"Slimefoot, the Stowaway and Wilson, Refined Grizzly cannot be commanders together",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := ParseDeck(strings.NewReader(test.deck))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, v := range ValidateCommanderDeck(r, d) {
+				got = append(got, v.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+				t.Errorf("want:\n%s\ngot:\n%s", strings.Join(test.want, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package mtg
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVColumns maps the fields of an entry to CSV header names. When reading, the
+// first name found in the header is used; when writing, the first name is used.
+// Fields without names are neither read nor written.
+type CSVColumns struct {
+	Count           []string
+	Name            []string
+	Set             []string
+	CollectorNumber []string
+	Foil            []string
+	Language        []string
+	Condition       []string
+	Section         []string
+}
+
+var (
+	DefaultCSVColumns = CSVColumns{
+		Count:           []string{"Count", "Quantity", "Qty", "Amount"},
+		Name:            []string{"Name", "Card Name", "Card"},
+		Set:             []string{"Set Code", "Edition Code", "Set", "Edition"},
+		CollectorNumber: []string{"Collector Number", "Card Number", "Number"},
+		Foil:            []string{"Foil", "Finish", "Printing"},
+		Language:        []string{"Language", "Lang"},
+		Condition:       []string{"Condition"},
+		Section:         []string{"Section", "Board"},
+	}
+	MoxfieldCSVColumns = CSVColumns{
+		Count:           []string{"Count"},
+		Name:            []string{"Name"},
+		Set:             []string{"Edition"},
+		CollectorNumber: []string{"Collector Number"},
+		Foil:            []string{"Foil"},
+		Language:        []string{"Language"},
+		Condition:       []string{"Condition"},
+	}
+	DeckboxCSVColumns = CSVColumns{
+		Count:           []string{"Count"},
+		Name:            []string{"Name"},
+		Set:             []string{"Edition Code"},
+		CollectorNumber: []string{"Card Number"},
+		Foil:            []string{"Foil"},
+		Language:        []string{"Language"},
+		Condition:       []string{"Condition"},
+	}
+	ManaBoxCSVColumns = CSVColumns{
+		Count:           []string{"Quantity"},
+		Name:            []string{"Name"},
+		Set:             []string{"Set code"},
+		CollectorNumber: []string{"Collector number"},
+		Foil:            []string{"Foil"},
+		Language:        []string{"Language"},
+		Condition:       []string{"Condition"},
+	}
+)
+
+type CSVCodec struct {
+	Columns CSVColumns
+}
+
+func ParseCSVDeck(in io.Reader, opts ...ParseOption) (Deck, error) {
+	return CSVCodec{Columns: DefaultCSVColumns}.Decode(in, opts...)
+}
+
+func WriteCSVDeck(w io.Writer, d Deck) error {
+	return CSVCodec{Columns: DefaultCSVColumns}.Encode(w, d)
+}
+
+// Decode reads a deck from CSV with a header row. Rows without a count column
+// are counted once.
+func (c CSVCodec) Decode(in io.Reader, opts ...ParseOption) (Deck, error) {
+	p := &deckParser{}
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return Deck{}, err
+		}
+	}
+
+	lines := &lineReader{r: bufio.NewReader(in), start: true}
+	r := csv.NewReader(lines)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err == io.EOF {
+		return Deck{Sections: []Section{{Name: Main}}}, nil
+	}
+	if err != nil {
+		return Deck{}, err
+	}
+	index := func(names []string) int {
+		for _, n := range names {
+			for i, h := range header {
+				if strings.EqualFold(strings.TrimSpace(h), n) {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+	var (
+		countCol     = index(c.Columns.Count)
+		nameCol      = index(c.Columns.Name)
+		setCol       = index(c.Columns.Set)
+		numberCol    = index(c.Columns.CollectorNumber)
+		foilCol      = index(c.Columns.Foil)
+		languageCol  = index(c.Columns.Language)
+		conditionCol = index(c.Columns.Condition)
+		sectionCol   = index(c.Columns.Section)
+	)
+	if nameCol < 0 {
+		return Deck{}, &ParseError{Line: 1, Text: strings.Join(header, ","), Reason: "missing name column"}
+	}
+
+	deck := Deck{}
+	var errs ParseErrors
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		// the record ends on the last line read, quoted fields may span lines
+		line := lines.lines - strings.Count(strings.Join(record, ""), "\n")
+		if err != nil {
+			cerr, ok := err.(*csv.ParseError)
+			if !ok {
+				return Deck{}, err
+			}
+			perr := &ParseError{Line: cerr.StartLine, Text: strings.Join(record, ","), Reason: err.Error()}
+			if p.strict {
+				return Deck{}, perr
+			}
+			errs = append(errs, perr)
+			continue
+		}
+		field := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		reason := ""
+		e := Entry{Count: 1, Line: line}
+		if countCol >= 0 {
+			n, err := strconv.Atoi(field(countCol))
+			switch {
+			case err != nil:
+				reason = "invalid count"
+			case n < 1:
+				reason = "count must be positive"
+			}
+			e.Count = n
+		}
+		e.Card.Name = field(nameCol)
+		if reason == "" && e.Card.Name == "" {
+			reason = "missing card name"
+		}
+		if reason != "" {
+			perr := &ParseError{Line: line, Text: strings.Join(record, ","), Reason: reason}
+			if p.strict {
+				return Deck{}, perr
+			}
+			errs = append(errs, perr)
+			continue
+		}
+		if set := field(setCol); set != "" {
+			e.Card.Version = &Version{Set: set, CollectorNumber: field(numberCol)}
+		}
+		e.Foil = parseFoil(field(foilCol))
+		e.Language = field(languageCol)
+		e.Condition = field(conditionCol)
+		section := Main
+		if s := field(sectionCol); s != "" {
+			section = s
+			if name, ok := SectionName(s); ok {
+				section = name
+			}
+		}
+		s := deck.Section(section)
+		s.Entries = append(s.Entries, e)
+	}
+	if len(deck.Sections) == 0 {
+		deck.Sections = append(deck.Sections, Section{Name: Main})
+	}
+	if len(errs) > 0 {
+		return deck, errs
+	}
+	return deck, nil
+}
+
+func sniffCSV(head []byte) bool {
+	line := string(head)
+	if i := strings.IndexAny(line, "\r\n"); i >= 0 {
+		line = line[:i]
+	}
+	header, err := csv.NewReader(strings.NewReader(line)).Read()
+	if err != nil || len(header) < 2 {
+		return false
+	}
+	for _, h := range header {
+		for _, n := range DefaultCSVColumns.Name {
+			if strings.EqualFold(strings.TrimSpace(h), n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseFoil(s string) bool {
+	switch strings.ToLower(s) {
+	case "foil", "etched", "true", "yes", "1":
+		return true
+	}
+	return false
+}
+
+// Encode writes the deck as CSV using the first name of each column as header.
+func (c CSVCodec) Encode(w io.Writer, d Deck) error {
+	type column struct {
+		names []string
+		value func(s Section, e Entry) string
+	}
+	version := func(e Entry) Version {
+		if e.Card.Version == nil {
+			return Version{}
+		}
+		return *e.Card.Version
+	}
+	all := []column{
+		{c.Columns.Count, func(s Section, e Entry) string { return strconv.Itoa(e.Count) }},
+		{c.Columns.Name, func(s Section, e Entry) string { return e.Card.Name }},
+		{c.Columns.Set, func(s Section, e Entry) string { return version(e).Set }},
+		{c.Columns.CollectorNumber, func(s Section, e Entry) string { return version(e).CollectorNumber }},
+		{c.Columns.Foil, func(s Section, e Entry) string {
+			if e.Foil {
+				return "foil"
+			}
+			return ""
+		}},
+		{c.Columns.Language, func(s Section, e Entry) string { return e.Language }},
+		{c.Columns.Condition, func(s Section, e Entry) string { return e.Condition }},
+		{c.Columns.Section, func(s Section, e Entry) string { return s.Name }},
+	}
+	var cols []column
+	var header []string
+	for _, col := range all {
+		if len(col.names) > 0 {
+			cols = append(cols, col)
+			header = append(header, col.names[0])
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, s := range d.Sections {
+		for _, e := range s.Entries {
+			record := make([]string, len(cols))
+			for i, col := range cols {
+				record[i] = col.value(s, e)
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// lineReader passes its input on line by line and counts the lines, so that a
+// csv.Reader has read no further than the last line of a record.
+type lineReader struct {
+	r     *bufio.Reader
+	lines int
+	start bool
+}
+
+func (l *lineReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		b, err := l.r.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		if l.start {
+			l.lines++
+		}
+		l.start = b == '\n'
+		p[n] = b
+		n++
+		if b == '\n' {
+			break
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,110 @@
+package mtg
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseCSVDeck(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{
+			name: "moxfield",
+			raw: `"Count","Tradelist Count","Name","Edition","Condition","Language","Foil","Tags","Last Modified","Collector Number"
+"4","0","Lightning Bolt","m11","Near Mint","English","foil","","2020-01-01","146"
+"20","0","Mountain","","Near Mint","English","","","2020-01-01",""
+`,
+		},
+		{
+			name: "deckbox",
+			raw: `Count,Tradelist Count,Name,Edition,Edition Code,Card Number,Condition,Language,Foil
+4,0,Lightning Bolt,Magic 2011,m11,146,Near Mint,English,foil
+20,0,Mountain,,,,Near Mint,English,
+`,
+		},
+		{
+			name: "manabox",
+			raw: `Name,Set code,Set name,Collector number,Foil,Rarity,Quantity,Condition,Language
+Lightning Bolt,m11,Magic 2011,146,foil,common,4,Near Mint,English
+Mountain,,,,normal,common,20,Near Mint,English
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := ParseCSVDeck(strings.NewReader(test.raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			es := d.Section(Main).Entries
+			if len(es) != 2 {
+				t.Fatalf("want: %d, got: %d", 2, len(es))
+			}
+			e := es[0]
+			if e.Count != 4 || e.Card.Name != "Lightning Bolt" || !e.Foil || e.Language != "English" || e.Condition != "Near Mint" {
+				t.Errorf("unexpected entry: %+v", e)
+			}
+			want := Version{Set: "m11", CollectorNumber: "146"}
+			if e.Card.Version == nil || *e.Card.Version != want {
+				t.Errorf("want: %v, got: %v", want, e.Card.Version)
+			}
+			if es[1].Count != 20 || es[1].Foil || es[1].Card.Version != nil {
+				t.Errorf("unexpected entry: %+v", es[1])
+			}
+		})
+	}
+}
+
+func TestParseCSVDeckLines(t *testing.T) {
+	raw := `Count,Name,Tags
+4,Lightning Bolt,"burn
+removal"
+
+0,Mountain,
+2,"Pyro
+blast",
+x,Shock,
+1,Sh"ock,
+`
+	d, err := ParseCSVDeck(strings.NewReader(raw))
+	errs, ok := err.(ParseErrors)
+	if !ok || len(errs) != 3 {
+		t.Fatalf("want: 3 parse errors, got: %v", err)
+	}
+	var lines []int
+	for _, e := range d.Section(Main).Entries {
+		lines = append(lines, e.Line)
+	}
+	for _, e := range errs {
+		lines = append(lines, e.Line)
+	}
+	if want, got := "[2 6 5 8 9]", fmt.Sprint(lines); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestWriteCSVDeck(t *testing.T) {
+	raw := `Count,Name,Set Code,Collector Number,Foil,Language,Condition,Section
+4,Lightning Bolt,m11,146,foil,English,Near Mint,Main
+2,Pyroblast,,,,,,Sideboard
+`
+
+	d, err := ParseCSVDeck(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := d.Section(Sideboard).Count(); n != 2 {
+		t.Errorf("want: %d, got: %d", 2, n)
+	}
+	buf := &bytes.Buffer{}
+	if err := WriteCSVDeck(buf, d); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != raw {
+		t.Errorf("want:\n%s\ngot:\n%s", raw, got)
+	}
+}
@@ -0,0 +1,275 @@
+package mtg
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// A CuttingMachine is a print and cut machine that finds the printed cards by
+// registration marks.
+type CuttingMachine string
+
+const (
+	Silhouette CuttingMachine = "silhouette"
+	Cricut     CuttingMachine = "cricut"
+)
+
+const (
+	cornerRadius       float64 = 3
+	registrationInset  float64 = 10
+	registrationSize   float64 = 5
+	registrationLength float64 = 20
+)
+
+// Bleed extends the images of the cards by mirroring their edges, so that small
+// cutting errors do not leave white borders. The gap between the cards is at
+// least twice the bleed, which must be less than half the width of a card.
+func Bleed(mm float64) PrinterOption {
+	return func(p *ProxyPrinter) error {
+		p.cuts.bleed = mm
+		return nil
+	}
+}
+
+// CutLines draws lines along the edges of the cards across the whole page, as
+// guides for a guillotine.
+func CutLines() PrinterOption {
+	return func(p *ProxyPrinter) error {
+		p.cuts.lines = true
+		return nil
+	}
+}
+
+// CutOutlines draws the outlines of the cards with rounded corners.
+func CutOutlines() PrinterOption {
+	return func(p *ProxyPrinter) error {
+		p.cuts.outlines = true
+		return nil
+	}
+}
+
+// RegistrationMarks draws the registration marks of a cutting machine instead
+// of crop marks.
+func RegistrationMarks(m CuttingMachine) PrinterOption {
+	return func(p *ProxyPrinter) error {
+		p.cuts.machine = m
+		return nil
+	}
+}
+
+// cutGuides are the guides printed on the fronts of the pages.
+type cutGuides struct {
+	bleed    float64
+	lines    bool
+	outlines bool
+	machine  CuttingMachine
+}
+
+// check checks the bleed, which must leave room for the image of the card to be
+// mirrored, and that the registration marks fit into the margins of the grid.
+func (c cutGuides) check(g pageGrid) error {
+	if c.bleed < 0 || c.bleed >= cardWidth/2 {
+		return fmt.Errorf("bleed must be at least 0mm and less than %gmm", cardWidth/2)
+	}
+	switch c.machine {
+	case "":
+		return nil
+	case Silhouette, Cricut:
+		need := registrationInset + registrationSize + 2
+		if g.x-c.bleed < need || g.y-c.bleed < need {
+			return fmt.Errorf("registration marks need a margin of %gmm", need)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown cutting machine: %s", c.machine)
+}
+
+// addPageGuides draws the guides of a page, before the cards are drawn.
+func (c cutGuides) addPageGuides(pdf *gofpdf.Fpdf, g pageGrid) {
+	if c.lines {
+		pdf.SetDrawColor(160, 160, 160)
+		for _, x := range g.edges(g.x, g.columns, g.cardW) {
+			pdf.Line(x, 0, x, g.height)
+		}
+		for _, y := range g.edges(g.y, g.rows, g.cardH) {
+			pdf.Line(0, y, g.width, y)
+		}
+		pdf.SetDrawColor(0, 0, 0)
+	}
+	switch c.machine {
+	case Silhouette:
+		addSilhouetteMarks(pdf, g)
+	case Cricut:
+		addCricutMarks(pdf, g)
+	default:
+		g.addCropMarks(pdf)
+	}
+}
+
+// addCardGuides draws the guides of a card, after it is drawn.
+func (c cutGuides) addCardGuides(pdf *gofpdf.Fpdf, x, y float64) {
+	if c.outlines {
+		pdf.SetDrawColor(160, 160, 160)
+		pdf.RoundedRect(x, y, cardWidth, cardHeight, cornerRadius, "1234", "D")
+		pdf.SetDrawColor(0, 0, 0)
+	}
+}
+
+// addSilhouetteMarks draws a square in the top left corner and corner lines in
+// the top right and bottom left corners of the page.
+func addSilhouetteMarks(pdf *gofpdf.Fpdf, grid pageGrid) {
+	lw := pdf.GetLineWidth()
+	r, g, b := pdf.GetFillColor()
+	pdf.SetFillColor(0, 0, 0)
+	pdf.Rect(registrationInset, registrationInset, registrationSize, registrationSize, "F")
+	pdf.SetLineWidth(0.5)
+	right, bottom := grid.width-registrationInset, grid.height-registrationInset
+	pdf.Line(right-registrationLength, registrationInset, right, registrationInset)
+	pdf.Line(right, registrationInset, right, registrationInset+registrationLength)
+	pdf.Line(registrationInset, bottom-registrationLength, registrationInset, bottom)
+	pdf.Line(registrationInset, bottom, registrationInset+registrationLength, bottom)
+	pdf.SetLineWidth(lw)
+	pdf.SetFillColor(r, g, b)
+}
+
+// addCricutMarks draws a frame around the page with a square in its top left
+// corner.
+func addCricutMarks(pdf *gofpdf.Fpdf, grid pageGrid) {
+	lw := pdf.GetLineWidth()
+	r, g, b := pdf.GetFillColor()
+	pdf.SetLineWidth(1)
+	pdf.Rect(registrationInset, registrationInset, grid.width-2*registrationInset, grid.height-2*registrationInset, "D")
+	pdf.SetFillColor(0, 0, 0)
+	pdf.Rect(registrationInset, registrationInset, registrationSize, registrationSize, "F")
+	pdf.SetLineWidth(lw)
+	pdf.SetFillColor(r, g, b)
+}
+
+// addCropMarks marks the edges of the cards in the margins of the page.
+func (g pageGrid) addCropMarks(pdf *gofpdf.Fpdf) {
+	markX := markLength(g.y)
+	markY := markLength(g.x)
+	for _, x := range g.edges(g.x, g.columns, g.cardW) {
+		if markX > 0 {
+			pdf.Line(x, 0, x, markX)
+			pdf.Line(x, g.height-markX, x, g.height)
+		}
+	}
+	for _, y := range g.edges(g.y, g.rows, g.cardH) {
+		if markY > 0 {
+			pdf.Line(0, y, markY, y)
+			pdf.Line(g.width-markY, y, g.width, y)
+		}
+	}
+}
+
+// edges returns the positions of the edges of the cards in a row or column.
+func (g pageGrid) edges(offset float64, n int, size float64) []float64 {
+	var es []float64
+	for i := 0; i < n; i++ {
+		start := offset + float64(i)*(size+g.gap)
+		if i == 0 || g.gap > 0 {
+			es = append(es, start)
+		}
+		es = append(es, start+size)
+	}
+	return es
+}
+
+// markLength returns the length of crop marks in a margin, they end 2mm before
+// the cards and are at most 10mm long.
+func markLength(margin float64) float64 {
+	l := margin - 2
+	if l > 10 {
+		l = 10
+	}
+	return l
+}
+
+// bleedImage returns an image in portrait orientation that is extended by the
+// bleed in mm on every side, mirroring the pixels at the edges. Landscape
+// images are rotated. The returned size is in mm.
+func bleedImage(data []byte, bleed float64) ([]byte, float64, float64, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	src := toRGBA(img)
+	if b := src.Bounds(); b.Dx() > b.Dy() {
+		src = rotateLeft(src)
+	}
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	n := int(bleed*float64(w)/cardWidth + 0.5)
+	buf := &bytes.Buffer{}
+	if err := jpeg.Encode(buf, extend(src, n), &jpeg.Options{Quality: 95}); err != nil {
+		return nil, 0, 0, err
+	}
+	return buf.Bytes(), cardWidth * float64(w+2*n) / float64(w), cardHeight * float64(h+2*n) / float64(h), nil
+}
+
+// extend extends an image by n pixels on every side, mirroring the pixels at
+// the edges.
+func extend(src *image.RGBA, n int) *image.RGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w+2*n, h+2*n))
+	draw.Draw(dst, image.Rect(n, n, n+w, n+h), src, image.Point{}, draw.Src)
+	for y := n; y < n+h; y++ {
+		for x := 0; x < n; x++ {
+			copyPixel(dst, x, y, dst, n+mirror(x-n, w), y)
+			copyPixel(dst, n+w+x, y, dst, n+mirror(w+x, w), y)
+		}
+	}
+	row := func(y int) []byte {
+		return dst.Pix[dst.PixOffset(0, y) : dst.PixOffset(0, y)+4*dst.Rect.Dx()]
+	}
+	for y := 0; y < n; y++ {
+		copy(row(y), row(n+mirror(y-n, h)))
+		copy(row(n+h+y), row(n+mirror(h+y, h)))
+	}
+	return dst
+}
+
+// mirror mirrors a coordinate outside of [0, n) at the edges.
+func mirror(i, n int) int {
+	switch {
+	case i < 0:
+		return -i - 1
+	case i >= n:
+		return 2*n - i - 1
+	}
+	return i
+}
+
+// toRGBA returns an image as RGBA with its origin at 0, 0.
+func toRGBA(src image.Image) *image.RGBA {
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+	return dst
+}
+
+// rotateLeft rotates an image by 90 degrees counterclockwise.
+func rotateLeft(src *image.RGBA) *image.RGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, h, w))
+	for y := 0; y < w; y++ {
+		for x := 0; x < h; x++ {
+			copyPixel(dst, x, y, src, w-1-y, x)
+		}
+	}
+	return dst
+}
+
+// copyPixel copies the pixel at sx, sy of src to x, y of dst.
+func copyPixel(dst *image.RGBA, x, y int, src *image.RGBA, sx, sy int) {
+	i, j := dst.PixOffset(x, y), src.PixOffset(sx, sy)
+	copy(dst.Pix[i:i+4], src.Pix[j:j+4])
+}
@@ -0,0 +1,151 @@
+package mtg
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestBleedImage(t *testing.T) {
+	tests := []struct {
+		width, height int
+		bleed         float64
+		want          string
+	}{
+		{630, 880, 3, "690x940 69.0x94.0"},
+		{880, 630, 3, "690x940 69.0x94.0"},
+		{630, 880, 0, "630x880 63.0x88.0"},
+	}
+	for _, test := range tests {
+		src := image.NewRGBA(image.Rect(0, 0, test.width, test.height))
+		src.Set(0, 0, color.RGBA{255, 0, 0, 255})
+		buf := &bytes.Buffer{}
+		if err := png.Encode(buf, src); err != nil {
+			t.Fatal(err)
+		}
+		data, w, h, err := bleedImage(buf.Bytes(), test.bleed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprintf("%dx%d %.1fx%.1f", cfg.Width, cfg.Height, w, h); test.want != got {
+			t.Errorf("want: %v, got: %v", test.want, got)
+		}
+	}
+}
+
+// pixels lists the red values of the rows of an image.
+func pixels(img *image.RGBA) string {
+	buf := &bytes.Buffer{}
+	for y := 0; y < img.Rect.Dy(); y++ {
+		if y > 0 {
+			buf.WriteString(" ")
+		}
+		for x := 0; x < img.Rect.Dx(); x++ {
+			fmt.Fprintf(buf, "%d", img.RGBAAt(x, y).R)
+		}
+	}
+	return buf.String()
+}
+
+func TestExtend(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	for i := 0; i < 6; i++ {
+		src.SetRGBA(i%3, i/3, color.RGBA{uint8(i + 1), 0, 0, 255})
+	}
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "123 456"},
+		{1, "11233 11233 44566 44566"},
+		{2, "5445665 2112332 2112332 5445665 5445665 2112332"},
+	}
+	for _, test := range tests {
+		if got := pixels(extend(src, test.n)); test.want != got {
+			t.Errorf("want: %v, got: %v", test.want, got)
+		}
+	}
+}
+
+func TestRotateLeft(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	for i := 0; i < 6; i++ {
+		src.SetRGBA(i%3, i/3, color.RGBA{uint8(i + 1), 0, 0, 255})
+	}
+	if want, got := "36 25 14", pixels(rotateLeft(src)); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestMirror(t *testing.T) {
+	tests := []struct {
+		i, n int
+		want int
+	}{
+		{-1, 10, 0},
+		{-3, 10, 2},
+		{0, 10, 0},
+		{9, 10, 9},
+		{10, 10, 9},
+		{12, 10, 7},
+	}
+	for _, test := range tests {
+		if got := mirror(test.i, test.n); test.want != got {
+			t.Errorf("want: %v, got: %v", test.want, got)
+		}
+	}
+}
+
+func TestCutGuidesCheck(t *testing.T) {
+	tests := []struct {
+		layout PageLayout
+		cuts   cutGuides
+		want   string
+	}{
+		{DefaultPageLayout, cutGuides{}, "<nil>"},
+		{DefaultPageLayout, cutGuides{machine: Silhouette}, "<nil>"},
+		{DefaultPageLayout, cutGuides{machine: Cricut}, "<nil>"},
+		{DefaultPageLayout, cutGuides{machine: Cricut, bleed: 1}, "registration marks need a margin of 17mm"},
+		{PageLayout{Size: "A4", Margin: 10}, cutGuides{machine: Silhouette}, "registration marks need a margin of 17mm"},
+		{DefaultPageLayout, cutGuides{machine: "plotter"}, "unknown cutting machine: plotter"},
+		{DefaultPageLayout, cutGuides{bleed: -1}, "bleed must be at least 0mm and less than 31.5mm"},
+		{DefaultPageLayout, cutGuides{bleed: 31.5}, "bleed must be at least 0mm and less than 31.5mm"},
+	}
+	for _, test := range tests {
+		g, err := test.layout.grid()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprint(test.cuts.check(g)); test.want != got {
+			t.Errorf("want: %v, got: %v", test.want, got)
+		}
+	}
+}
+
+func TestProxyPrinterGrid(t *testing.T) {
+	tests := []struct {
+		opts []PrinterOption
+		want string
+	}{
+		{nil, "4x2 gap 0.0"},
+		{[]PrinterOption{Bleed(2)}, "4x2 gap 4.0"},
+		{[]PrinterOption{Layout(PageLayout{Size: "A4", Landscape: true, Margin: 10, Gap: 5}), Bleed(2)}, "4x2 gap 5.0"},
+		{[]PrinterOption{Bleed(10)}, "3x1 gap 20.0"},
+	}
+	for _, test := range tests {
+		g, err := NewProxyPrinter(nil, Deck{}, test.opts...).grid()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprintf("%dx%d gap %.1f", g.columns, g.rows, g.gap); test.want != got {
+			t.Errorf("want: %v, got: %v", test.want, got)
+		}
+	}
+}
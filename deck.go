@@ -2,6 +2,7 @@ package mtg
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"strconv"
 	"strings"
@@ -12,19 +13,79 @@ type Deck struct {
 	Sections []Section
 }
 
-func (d Deck) Cards() []Card {
-	var cards []Card
+func (d Deck) Cards() Cards {
+	var cards Cards
 	for _, s := range d.Sections {
-		for _, c := range s.Cards {
-			cards = append(cards, c)
-		}
+		cards = append(cards, s.Cards()...)
 	}
 	return cards
 }
 
+func (d Deck) Count() int {
+	n := 0
+	for _, s := range d.Sections {
+		n += s.Count()
+	}
+	return n
+}
+
 type Section struct {
-	Name  string
-	Cards Cards
+	Name    string
+	Entries []Entry
+}
+
+// Cards expands the entries of the section into the individual copies of the cards.
+func (s Section) Cards() Cards {
+	var cards Cards
+	for _, e := range s.Entries {
+		for i := 0; i < e.Count; i++ {
+			cards = append(cards, e.Card)
+		}
+	}
+	return cards
+}
+
+func (s Section) Count() int {
+	n := 0
+	for _, e := range s.Entries {
+		n += e.Count
+	}
+	return n
+}
+
+// An Entry is a single line of a deck list, e.g.:
+//
+//	4 [DOM:205] Slimefoot, the Stowaway # !Commander
+type Entry struct {
+	Count     int
+	Card      Card
+	Foil      bool
+	Language  string
+	Condition string
+	Line      int
+	Comment   string
+	Tags      []string
+	// Label is printed on the proxies of the entry instead of the deck name.
+	Label string
+}
+
+func (e Entry) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTags(comment string) []string {
+	var tags []string
+	for _, f := range strings.Fields(comment) {
+		if len(f) > 1 && strings.HasPrefix(f, "!") {
+			tags = append(tags, f[1:])
+		}
+	}
+	return tags
 }
 
 type Cards []Card
@@ -44,16 +105,31 @@ func CardByName(name string) func(Card) bool {
 	}
 }
 
+func sameCard(card Card) func(Card) bool {
+	return func(c Card) bool {
+		if c.Name != card.Name || (c.Version == nil) != (card.Version == nil) {
+			return false
+		}
+		return c.Version == nil || *c.Version == *card.Version
+	}
+}
+
 type Card struct {
-	Name       string
-	ManaCost   string
-	TypeLine   string
-	OracleText string
-	Power      string
-	Toughness  string
-	Loyalty    string
-	ImageData  []byte
-	Version    *Version
+	Name         string
+	ManaCost     string
+	CMC          float64
+	Colors       []string
+	TypeLine     string
+	OracleText   string
+	Power        string
+	Toughness    string
+	Loyalty      string
+	ProducedMana []string
+	ImageData    []byte
+	Version      *Version
+	MtgoID       int
+	OracleID     string
+	Layout       string
 }
 
 type Version struct {
@@ -61,50 +137,227 @@ type Version struct {
 	CollectorNumber string
 }
 
-func ParseDeck(in io.Reader) (Deck, error) {
+func (v Version) String() string {
+	if v.CollectorNumber == "" {
+		return v.Set
+	}
+	return v.Set + ":" + v.CollectorNumber
+}
+
+const (
+	Main       = "Main"
+	Sideboard  = "Sideboard"
+	Commander  = "Commander"
+	Companion  = "Companion"
+	Maybeboard = "Maybeboard"
+)
+
+var sectionNames = map[string]string{
+	"main":        Main,
+	"mainboard":   Main,
+	"maindeck":    Main,
+	"main deck":   Main,
+	"deck":        Main,
+	"sideboard":   Sideboard,
+	"side":        Sideboard,
+	"sb":          Sideboard,
+	"commander":   Commander,
+	"commanders":  Commander,
+	"companion":   Companion,
+	"maybeboard":  Maybeboard,
+	"maybe":       Maybeboard,
+	"considering": Maybeboard,
+}
+
+// SectionName returns the canonical name of the section introduced by a header
+// like "Sideboard", "Sideboard:" or "Sideboard (15)".
+func SectionName(header string) (string, bool) {
+	h := strings.TrimSpace(header)
+	h = strings.TrimSuffix(h, ":")
+	if i := strings.LastIndex(h, "("); i > 0 && strings.HasSuffix(h, ")") {
+		if _, err := strconv.Atoi(h[i+1 : len(h)-1]); err == nil {
+			h = strings.TrimSpace(h[:i])
+		}
+	}
+	name, ok := sectionNames[strings.ToLower(h)]
+	return name, ok
+}
+
+func (d *Deck) Section(name string) *Section {
+	for i := range d.Sections {
+		if d.Sections[i].Name == name {
+			return &d.Sections[i]
+		}
+	}
+	d.Sections = append(d.Sections, Section{Name: name})
+	return &d.Sections[len(d.Sections)-1]
+}
+
+type ParseOption func(*deckParser) error
+
+// Strict makes ParseDeck fail on the first line that cannot be parsed
+// instead of dropping it.
+func Strict() ParseOption {
+	return func(p *deckParser) error {
+		p.strict = true
+		return nil
+	}
+}
+
+type deckParser struct {
+	strict bool
+}
+
+// A ParseError describes a line of a deck list that could not be parsed.
+type ParseError struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s: %q", e.Line, e.Reason, e.Text)
+}
+
+// ParseErrors is returned by ParseDeck alongside the parsed deck if some
+// lines had to be dropped.
+type ParseErrors []*ParseError
+
+func (es ParseErrors) Error() string {
+	switch len(es) {
+	case 0:
+		return "no errors"
+	case 1:
+		return es[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", es[0], len(es)-1)
+}
+
+// ParseDeck parses a deck list. Lines that cannot be parsed are dropped and
+// reported as ParseErrors alongside the deck, so the error is not nil although
+// the deck can be used. Callers that accept dropped lines check for ParseErrors.
+// With the Strict option parsing stops with a *ParseError instead.
+func ParseDeck(in io.Reader, opts ...ParseOption) (Deck, error) {
+	p := &deckParser{}
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return Deck{}, err
+		}
+	}
+
 	deck := Deck{}
-	currentSection := Section{Name: "Main"}
+	var errs ParseErrors
+	current := Main
+	structured := false
+	blank := false
+	lineNumber := 0
 	scanner := bufio.NewScanner(in)
 	for scanner.Scan() {
-		line := scanner.Text()
+		lineNumber++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
 		if strings.HasPrefix(line, "//") {
+			structured = true
+			if name, ok := SectionName(line[2:]); ok {
+				current = name
+			}
 			continue
 		}
+		if line == "" {
+			blank = true
+			continue
+		}
+		comment := ""
 		if i := strings.Index(line, "#"); i >= 0 {
+			comment = strings.TrimSpace(line[i+1:])
 			line = line[:i]
 		}
 		line = strings.TrimSpace(line)
 		if line == "" {
+			// a comment of its own
 			continue
 		}
-		card := Card{}
-		if i := strings.Index(line, "["); i >= 0 {
-			if o := strings.Index(line, "]"); o > i {
-				version := line[i+1 : o]
-				vps := strings.Split(version, ":")
-				switch len(vps) {
-				case 1:
-					card.Version = &Version{Set: vps[0]}
-				case 2:
-					card.Version = &Version{Set: vps[0], CollectorNumber: vps[1]}
-				}
-				line = line[:i] + line[o+1:]
-			}
-		}
-		fs := strings.Fields(line)
-		if len(fs) < 2 {
+		if name, ok := SectionName(line); ok {
+			structured = true
+			current = name
+			blank = false
 			continue
 		}
-		c, err := strconv.ParseInt(fs[0], 10, 64)
-		if err != nil {
+		if len(line) > 1 && strings.HasSuffix(line, ":") {
+			// a section of its own, as written by WriteDeck
+			structured = true
+			current = strings.TrimSpace(line[:len(line)-1])
+			blank = false
 			continue
 		}
-		card.Name = strings.Join(fs[1:], " ")
-		n := int(c)
-		for i := 0; i < n; i++ {
-			currentSection.Cards = append(currentSection.Cards, card)
+		if blank && !structured && current == Main && len(deck.Sections) > 0 {
+			// MTGO and Arena separate the sideboard by a blank line
+			current = Sideboard
 		}
+		blank = false
+		section := current
+		if len(line) > 3 && strings.EqualFold(line[:3], "SB:") {
+			section = Sideboard
+			line = strings.TrimSpace(line[3:])
+		}
+		e, reason := parseEntry(line)
+		if reason != "" {
+			err := &ParseError{Line: lineNumber, Text: raw, Reason: reason}
+			if p.strict {
+				return Deck{}, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		e.Line = lineNumber
+		e.Comment = comment
+		e.Tags = parseTags(comment)
+		s := deck.Section(section)
+		s.Entries = append(s.Entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return Deck{}, err
+	}
+	if len(deck.Sections) == 0 {
+		deck.Sections = append(deck.Sections, Section{Name: Main})
+	}
+	if len(errs) > 0 {
+		return deck, errs
 	}
-	deck.Sections = append(deck.Sections, currentSection)
 	return deck, nil
 }
+
+func parseEntry(line string) (Entry, string) {
+	card := Card{}
+	if i := strings.Index(line, "["); i >= 0 {
+		o := strings.Index(line, "]")
+		if o < i {
+			return Entry{}, "unmatched ["
+		}
+		vps := strings.Split(line[i+1:o], ":")
+		switch {
+		case len(vps) == 1 && vps[0] != "":
+			card.Version = &Version{Set: vps[0]}
+		case len(vps) == 2 && vps[0] != "":
+			card.Version = &Version{Set: vps[0], CollectorNumber: vps[1]}
+		default:
+			return Entry{}, "invalid version, want [SET] or [SET:NUMBER]"
+		}
+		line = line[:i] + line[o+1:]
+	} else if strings.Contains(line, "]") {
+		return Entry{}, "unmatched ]"
+	}
+	fs := strings.Fields(line)
+	if len(fs) < 2 {
+		return Entry{}, "missing count or card name"
+	}
+	c, err := strconv.ParseInt(fs[0], 10, 64)
+	if err != nil {
+		return Entry{}, "invalid count"
+	}
+	if c < 1 {
+		return Entry{}, "count must be positive"
+	}
+	card.Name = strings.Join(fs[1:], " ")
+	return Entry{Count: int(c), Card: card}, ""
+}
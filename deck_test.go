@@ -32,3 +32,170 @@ func TestParseDeck(t *testing.T) {
 	}
 
 }
+
+func TestParseDeckSections(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want map[string]int
+	}{
+		{
+			name: "comment headers",
+			raw: `//Main
+4 Lightning Bolt
+// Creatures
+4 Goblin Guide
+//Sideboard
+2 Smash to Smithereens
+`,
+			want: map[string]int{Main: 8, Sideboard: 2},
+		},
+		{
+			name: "colon headers",
+			raw: `Commander:
+1 Slimefoot, the Stowaway
+Deck:
+1 Forest
+Sideboard:
+1 Swamp
+Maybeboard:
+1 Island
+`,
+			want: map[string]int{Commander: 1, Main: 1, Sideboard: 1, Maybeboard: 1},
+		},
+		{
+			name: "sb prefix",
+			raw: `4 Lightning Bolt
+SB: 2 Smash to Smithereens
+4 Goblin Guide
+`,
+			want: map[string]int{Main: 8, Sideboard: 2},
+		},
+		{
+			name: "blank line",
+			raw: `
+4 Lightning Bolt
+4 Goblin Guide
+
+2 Smash to Smithereens
+
+1 Pyroblast
+`,
+			want: map[string]int{Main: 8, Sideboard: 3},
+		},
+		{
+			name: "blank line after comment",
+			raw: `# Burn
+4 Lightning Bolt
+4 Goblin Guide
+
+# answers
+2 Smash to Smithereens
+`,
+			want: map[string]int{Main: 8, Sideboard: 2},
+		},
+		{
+			name: "arena headers",
+			raw: `Commander
+1 Slimefoot, the Stowaway
+
+Companion
+1 Lurrus of the Dream-Den
+
+Deck
+40 Forest
+`,
+			want: map[string]int{Commander: 1, Companion: 1, Main: 40},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := ParseDeck(strings.NewReader(test.raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]int{}
+			for _, s := range d.Sections {
+				got[s.Name] = s.Count()
+			}
+			if len(got) != len(test.want) {
+				t.Errorf("want: %v, got: %v", test.want, got)
+			}
+			for name, n := range test.want {
+				if got[name] != n {
+					t.Errorf("%s: want: %d, got: %d", name, n, got[name])
+				}
+			}
+		})
+	}
+}
+
+func TestParseDeckEntries(t *testing.T) {
+	raw := `
+//Main
+1 [DOM:205] Slimefoot, the Stowaway # !Commander
+4 Forest # basics
+`
+
+	d, err := ParseDeck(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Sections) != 1 {
+		t.Fatalf("want: %d, got: %d", 1, len(d.Sections))
+	}
+	es := d.Sections[0].Entries
+	if len(es) != 2 {
+		t.Fatalf("want: %d, got: %d", 2, len(es))
+	}
+	if es[0].Line != 3 || es[0].Count != 1 || !es[0].HasTag("Commander") {
+		t.Errorf("unexpected entry: %+v", es[0])
+	}
+	if es[1].Line != 4 || es[1].Count != 4 || es[1].Comment != "basics" || len(es[1].Tags) != 0 {
+		t.Errorf("unexpected entry: %+v", es[1])
+	}
+	if n := d.Count(); n != 5 {
+		t.Errorf("want: %d, got: %d", 5, n)
+	}
+	if n := len(d.Cards()); n != 5 {
+		t.Errorf("want: %d, got: %d", 5, n)
+	}
+}
+
+func TestParseDeckErrors(t *testing.T) {
+	raw := `4 Lightning Bolt
+Goblin Guide
+x Monastery Swiftspear
+2 [M19 Lightning Strike
+4 Mountain
+`
+
+	d, err := ParseDeck(strings.NewReader(raw))
+	errs, ok := err.(ParseErrors)
+	if !ok {
+		t.Fatalf("want: ParseErrors, got: %v", err)
+	}
+	if len(errs) != 3 {
+		t.Fatalf("want: %d, got: %d", 3, len(errs))
+	}
+	for i, line := range []int{2, 3, 4} {
+		if errs[i].Line != line {
+			t.Errorf("want: %d, got: %d", line, errs[i].Line)
+		}
+	}
+	if errs[1].Text != "x Monastery Swiftspear" {
+		t.Errorf("want: %q, got: %q", "x Monastery Swiftspear", errs[1].Text)
+	}
+	if n := d.Count(); n != 8 {
+		t.Errorf("want: %d, got: %d", 8, n)
+	}
+
+	_, err = ParseDeck(strings.NewReader(raw), Strict())
+	perr, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("want: *ParseError, got: %v", err)
+	}
+	if perr.Line != 2 {
+		t.Errorf("want: %d, got: %d", 2, perr.Line)
+	}
+}
@@ -0,0 +1,177 @@
+package mtg
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type ChangeKind string
+
+const (
+	CardAdded       ChangeKind = "added"
+	CardRemoved     ChangeKind = "removed"
+	PrintingChanged ChangeKind = "printing"
+)
+
+// A Change is a difference of a card between two versions of a deck. Added
+// and removed cards have the printing in To respectively From.
+type Change struct {
+	Section string
+	Kind    ChangeKind
+	Name    string
+	Count   int
+	From    *Version
+	To      *Version
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case CardAdded:
+		return "+" + FormatEntry(Entry{Count: c.Count, Card: Card{Name: c.Name, Version: c.To}})
+	case CardRemoved:
+		return "-" + FormatEntry(Entry{Count: c.Count, Card: Card{Name: c.Name, Version: c.From}})
+	}
+	return fmt.Sprintf("~%d %s: %s -> %s", c.Count, c.Name, printing(c.From), printing(c.To))
+}
+
+func printing(v *Version) string {
+	if v == nil {
+		return "any"
+	}
+	return v.String()
+}
+
+// DiffDecks returns the changes per section from deck a to deck b. Copies of a
+// card that have been replaced by another printing are reported as printing
+// changes.
+func DiffDecks(a, b Deck) []Change {
+	var names []string
+	for _, d := range []Deck{a, b} {
+		for _, s := range d.Sections {
+			if !containsString(names, s.Name) {
+				names = append(names, s.Name)
+			}
+		}
+	}
+	var cs []Change
+	for _, name := range names {
+		cs = append(cs, diffSections(name, sectionEntries(a, name), sectionEntries(b, name))...)
+	}
+	return cs
+}
+
+func sectionEntries(d Deck, name string) []Entry {
+	var es []Entry
+	for _, s := range d.Sections {
+		if s.Name == name {
+			es = append(es, s.Entries...)
+		}
+	}
+	return es
+}
+
+type printingCount struct {
+	version *Version
+	count   int
+}
+
+func diffSections(section string, a, b []Entry) []Change {
+	// delta of copies per card name and printing
+	deltas := map[string]map[string]*printingCount{}
+	var cards []string
+	add := func(es []Entry, sign int) {
+		for _, e := range es {
+			ps, ok := deltas[e.Card.Name]
+			if !ok {
+				ps = map[string]*printingCount{}
+				deltas[e.Card.Name] = ps
+				cards = append(cards, e.Card.Name)
+			}
+			v := versionString(e.Card.Version)
+			if _, ok := ps[v]; !ok {
+				ps[v] = &printingCount{version: e.Card.Version}
+			}
+			ps[v].count += sign * e.Count
+		}
+	}
+	add(a, -1)
+	add(b, 1)
+	sort.Slice(cards, func(i, j int) bool { return strings.ToLower(cards[i]) < strings.ToLower(cards[j]) })
+
+	var cs []Change
+	for _, card := range cards {
+		var keys []string
+		for k := range deltas[card] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var removed, added []*printingCount
+		for _, k := range keys {
+			p := deltas[card][k]
+			switch {
+			case p.count < 0:
+				removed = append(removed, &printingCount{version: p.version, count: -p.count})
+			case p.count > 0:
+				added = append(added, p)
+			}
+		}
+		var moved []Change
+		for len(removed) > 0 && len(added) > 0 {
+			r, a := removed[0], added[0]
+			n := r.count
+			if a.count < n {
+				n = a.count
+			}
+			moved = append(moved, Change{Section: section, Kind: PrintingChanged, Name: card, Count: n, From: r.version, To: a.version})
+			r.count -= n
+			a.count -= n
+			if r.count == 0 {
+				removed = removed[1:]
+			}
+			if a.count == 0 {
+				added = added[1:]
+			}
+		}
+		for _, r := range removed {
+			cs = append(cs, Change{Section: section, Kind: CardRemoved, Name: card, Count: r.count, From: r.version})
+		}
+		for _, a := range added {
+			cs = append(cs, Change{Section: section, Kind: CardAdded, Name: card, Count: a.count, To: a.version})
+		}
+		cs = append(cs, moved...)
+	}
+	return cs
+}
+
+func containsString(ss []string, s string) bool {
+	for _, o := range ss {
+		if o == s {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteDiff writes the changes grouped by section.
+func WriteDiff(w io.Writer, cs []Change) error {
+	section := ""
+	for i, c := range cs {
+		if c.Section != section {
+			if i > 0 {
+				if _, err := fmt.Fprintln(w); err != nil {
+					return err
+				}
+			}
+			if _, err := fmt.Fprintf(w, "//%s\n", c.Section); err != nil {
+				return err
+			}
+			section = c.Section
+		}
+		if _, err := fmt.Fprintln(w, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package mtg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDiffDecks(t *testing.T) {
+	a, err := ParseDeck(strings.NewReader(`4 [M10:133] Lightning Bolt
+2 Shock
+20 Mountain
+//Sideboard
+2 Pyroblast
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ParseDeck(strings.NewReader(`1 [M10:133] Lightning Bolt
+3 [M11:146] Lightning Bolt
+1 Shock
+2 Lava Spike
+20 Mountain
+//Sideboard
+3 Pyroblast
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteDiff(buf, DiffDecks(a, b)); err != nil {
+		t.Fatal(err)
+	}
+	want := `//Main
++2 Lava Spike
+~3 Lightning Bolt: M10:133 -> M11:146
+-1 Shock
+
+//Sideboard
++1 Pyroblast
+`
+	if got := buf.String(); want != got {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+
+	if cs := DiffDecks(a, a); len(cs) != 0 {
+		t.Errorf("want: no changes, got: %v", cs)
+	}
+}
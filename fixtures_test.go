@@ -0,0 +1,69 @@
+package mtg
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cognicraft/mtg/scryfall"
+)
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slug(s string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+// newTestScryfall returns a client for a server that answers with the recorded
+// responses in testdata/scryfall. URLs in the responses that start with a slash,
+// like those of images and related cards, point at the server.
+func newTestScryfall(t *testing.T) (*scryfall.Client, func()) {
+	dir := filepath.Join("testdata", "scryfall")
+	notFound := []byte(`{"object":"error","code":"not_found","status":404,"details":"No card found"}`)
+
+	var s *httptest.Server
+	s = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var file string
+		switch ps := strings.Split(strings.Trim(r.URL.Path, "/"), "/"); {
+		case r.URL.Path == "/cards/named":
+			file = filepath.Join(dir, "named", slug(r.URL.Query().Get("fuzzy"))+".json")
+			if set := r.URL.Query().Get("set"); set != "" {
+				file = filepath.Join(dir, "named", slug(r.URL.Query().Get("fuzzy"))+"-"+slug(set)+".json")
+			}
+		case r.URL.Path == "/cards/search":
+			file = filepath.Join(dir, "search", slug(r.URL.Query().Get("q"))+".json")
+		case r.URL.Path == "/cards/autocomplete":
+			file = filepath.Join(dir, "autocomplete", slug(r.URL.Query().Get("q"))+".json")
+		case len(ps) == 4 && ps[0] == "cards":
+			file = filepath.Join(dir, "cards", slug(ps[1])+"-"+slug(ps[2])+"-"+ps[3]+".json")
+		case len(ps) == 2 && ps[0] == "cards":
+			file = filepath.Join(dir, "cards", ps[1]+".json")
+		case len(ps) == 3 && ps[0] == "cards" && ps[1] == "mtgo":
+			file = filepath.Join(dir, "cards", "mtgo-"+ps[2]+".json")
+		default:
+			file = filepath.Join(dir, filepath.FromSlash(strings.Trim(r.URL.Path, "/")))
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			data = notFound
+		}
+		if strings.HasSuffix(file, ".json") {
+			w.Header().Set("Content-Type", "application/json")
+			data = bytes.Replace(data, []byte(`":"/`), []byte(`":"`+s.URL+`/`), -1)
+		}
+		if err != nil || strings.HasPrefix(string(data), `{"object":"error"`) {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Write(data)
+	}))
+	c, err := scryfall.New(scryfall.BaseURL(s.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, s.Close
+}
@@ -0,0 +1,139 @@
+package mtg
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseForgeDeck parses a Forge .dck file, e.g.:
+//
+//	[metadata]
+//	Name=Slimefoot
+//	[Commander]
+//	1 Slimefoot, the Stowaway|DOM|1
+//	[Main]
+//	4 Forest|DOM
+//
+// The optional art index of an entry is not a collector number and is ignored.
+func ParseForgeDeck(in io.Reader, opts ...ParseOption) (Deck, error) {
+	p := &deckParser{}
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return Deck{}, err
+		}
+	}
+
+	deck := Deck{}
+	var errs ParseErrors
+	current := Main
+	metadata := false
+	lineNumber := 0
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		lineNumber++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			header := line[1 : len(line)-1]
+			metadata = strings.EqualFold(header, "metadata")
+			current = header
+			if name, ok := SectionName(header); ok {
+				current = name
+			}
+			continue
+		}
+		if metadata {
+			if i := strings.Index(line, "="); i >= 0 && strings.EqualFold(line[:i], "Name") {
+				deck.Name = line[i+1:]
+			}
+			continue
+		}
+		e, reason := parseForgeEntry(line)
+		if reason != "" {
+			err := &ParseError{Line: lineNumber, Text: raw, Reason: reason}
+			if p.strict {
+				return Deck{}, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		e.Line = lineNumber
+		s := deck.Section(current)
+		s.Entries = append(s.Entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return Deck{}, err
+	}
+	if len(deck.Sections) == 0 {
+		deck.Sections = append(deck.Sections, Section{Name: Main})
+	}
+	if len(errs) > 0 {
+		return deck, errs
+	}
+	return deck, nil
+}
+
+func parseForgeEntry(line string) (Entry, string) {
+	i := strings.Index(line, " ")
+	if i < 0 {
+		return Entry{}, "missing count or card name"
+	}
+	c, err := strconv.Atoi(line[:i])
+	if err != nil {
+		return Entry{}, "invalid count"
+	}
+	if c < 1 {
+		return Entry{}, "count must be positive"
+	}
+	ps := strings.Split(strings.TrimSpace(line[i+1:]), "|")
+	card := Card{Name: strings.TrimSpace(ps[0])}
+	if card.Name == "" {
+		return Entry{}, "missing card name"
+	}
+	if len(ps) > 1 && ps[1] != "" {
+		card.Version = &Version{Set: ps[1]}
+	}
+	return Entry{Count: c, Card: card}, ""
+}
+
+// WriteForgeDeck writes the deck as a Forge .dck file. Companions are moved to the
+// sideboard and the maybeboard is omitted.
+func WriteForgeDeck(w io.Writer, d Deck) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "[metadata]\nName=%s\n", d.Name)
+	var order []string
+	sections := map[string][]Entry{}
+	for _, s := range d.Sections {
+		if len(s.Entries) == 0 {
+			continue
+		}
+		name := s.Name
+		switch name {
+		case Maybeboard:
+			continue
+		case Companion:
+			name = Sideboard
+		}
+		if _, ok := sections[name]; !ok {
+			order = append(order, name)
+		}
+		sections[name] = append(sections[name], s.Entries...)
+	}
+	for _, name := range order {
+		fmt.Fprintf(bw, "[%s]\n", name)
+		for _, e := range sections[name] {
+			fmt.Fprintf(bw, "%d %s", e.Count, e.Card.Name)
+			if v := e.Card.Version; v != nil && v.Set != "" {
+				fmt.Fprintf(bw, "|%s", strings.ToUpper(v.Set))
+			}
+			fmt.Fprintln(bw)
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,48 @@
+package mtg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseForgeDeck(t *testing.T) {
+	raw := `[metadata]
+Name=Slimefoot
+[Commander]
+1 Slimefoot, the Stowaway|DOM
+[Main]
+1 Sol Ring|C19
+98 Forest
+[Sideboard]
+1 Naturalize|M19
+`
+
+	d, err := ParseForgeDeck(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Name != "Slimefoot" {
+		t.Errorf("want: %q, got: %q", "Slimefoot", d.Name)
+	}
+	want := map[string]int{Commander: 1, Main: 99, Sideboard: 1}
+	if len(d.Sections) != len(want) {
+		t.Fatalf("want: %d, got: %d", len(want), len(d.Sections))
+	}
+	for _, s := range d.Sections {
+		if s.Count() != want[s.Name] {
+			t.Errorf("%s: want: %d, got: %d", s.Name, want[s.Name], s.Count())
+		}
+	}
+	if v := d.Section(Commander).Entries[0].Card.Version; v == nil || v.Set != "DOM" {
+		t.Errorf("want: %s, got: %v", "DOM", v)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteForgeDeck(buf, d); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != raw {
+		t.Errorf("want:\n%s\ngot:\n%s", raw, got)
+	}
+}
@@ -0,0 +1,142 @@
+package mtg
+
+import (
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/cognicraft/mtg/scryfall"
+)
+
+const mtgoHeader = `<?xml version="1.0" encoding="utf-8"?>` + "\n"
+
+type mtgoDeck struct {
+	XMLName              xml.Name    `xml:"Deck"`
+	XSD                  string      `xml:"xmlns:xsd,attr"`
+	XSI                  string      `xml:"xmlns:xsi,attr"`
+	NetDeckID            int         `xml:"NetDeckID"`
+	PreconstructedDeckID int         `xml:"PreconstructedDeckID"`
+	Cards                []mtgoCards `xml:"Cards"`
+}
+
+type mtgoCards struct {
+	CatID      int    `xml:"CatID,attr,omitempty"`
+	Quantity   int    `xml:"Quantity,attr"`
+	Sideboard  bool   `xml:"Sideboard,attr"`
+	Name       string `xml:"Name,attr,omitempty"`
+	Annotation int    `xml:"Annotation,attr"`
+}
+
+// ParseMTGODeck parses a Magic Online .dek file. Cards without a name only carry
+// their catalog ID, which can be resolved with ResolveMtgoIDs.
+func ParseMTGODeck(in io.Reader, opts ...ParseOption) (Deck, error) {
+	p := &deckParser{}
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return Deck{}, err
+		}
+	}
+
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return Deck{}, err
+	}
+	md := mtgoDeck{}
+	if err := xml.Unmarshal(data, &md); err != nil {
+		return Deck{}, err
+	}
+	lines, err := xmlElementLines(data, "Deck", "Cards")
+	if err != nil {
+		return Deck{}, err
+	}
+	deck := Deck{}
+	var errs ParseErrors
+	for i, mc := range md.Cards {
+		if mc.Quantity < 1 || (mc.Name == "" && mc.CatID == 0) {
+			err := &ParseError{Line: lines[i], Text: mc.Name, Reason: "missing quantity, name or catalog ID"}
+			if p.strict {
+				return Deck{}, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		section := Main
+		if mc.Sideboard {
+			section = Sideboard
+		}
+		s := deck.Section(section)
+		s.Entries = append(s.Entries, Entry{
+			Count: mc.Quantity,
+			Card:  Card{Name: mc.Name, MtgoID: mc.CatID},
+			Line:  lines[i],
+		})
+	}
+	if len(deck.Sections) == 0 {
+		deck.Sections = append(deck.Sections, Section{Name: Main})
+	}
+	if len(errs) > 0 {
+		return deck, errs
+	}
+	return deck, nil
+}
+
+// WriteMTGODeck writes the deck as a Magic Online .dek file. All sections but
+// the main deck and the maybeboard end up in the sideboard.
+func WriteMTGODeck(w io.Writer, d Deck) error {
+	md := mtgoDeck{
+		XSD: "http://www.w3.org/2001/XMLSchema",
+		XSI: "http://www.w3.org/2001/XMLSchema-instance",
+	}
+	for _, s := range d.Sections {
+		if s.Name == Maybeboard {
+			continue
+		}
+		for _, e := range s.Entries {
+			md.Cards = append(md.Cards, mtgoCards{
+				CatID:     e.Card.MtgoID,
+				Quantity:  e.Count,
+				Sideboard: s.Name != Main,
+				Name:      e.Card.Name,
+			})
+		}
+	}
+	if _, err := io.WriteString(w, mtgoHeader); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(md); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ResolveMtgoIDs looks up the names and printings of all cards that are only
+// known by their Magic Online catalog ID.
+func ResolveMtgoIDs(client *scryfall.Client, d Deck) (Deck, error) {
+	notFound := &NotFoundError{}
+	sections := make([]Section, len(d.Sections))
+	for i, s := range d.Sections {
+		entries := make([]Entry, 0, len(s.Entries))
+		for _, e := range s.Entries {
+			if e.Card.Name == "" && e.Card.MtgoID != 0 {
+				sc := client.CardByMtgoID(e.Card.MtgoID)
+				if sc == nil {
+					notFound.Cards = append(notFound.Cards, e.Card)
+					continue
+				}
+				e.Card.Name = sc.Name
+				e.Card.Version = &Version{Set: strings.ToUpper(sc.Set), CollectorNumber: sc.CollectorNumber}
+			}
+			entries = append(entries, e)
+		}
+		sections[i] = Section{Name: s.Name, Entries: entries}
+	}
+	d.Sections = sections
+	if len(notFound.Cards) > 0 {
+		return d, notFound
+	}
+	return d, nil
+}
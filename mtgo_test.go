@@ -0,0 +1,65 @@
+package mtg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseMTGODeck(t *testing.T) {
+	raw := `<?xml version="1.0" encoding="utf-8"?>
+<Deck xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <NetDeckID>0</NetDeckID>
+  <PreconstructedDeckID>0</PreconstructedDeckID>
+  <Cards CatID="48394" Quantity="4" Sideboard="false" Name="Lightning Bolt" Annotation="0"></Cards>
+  <Cards CatID="81933" Quantity="20" Sideboard="false" Annotation="0"></Cards>
+  <Cards CatID="27680" Quantity="2" Sideboard="true" Name="Pyroblast" Annotation="0"></Cards>
+</Deck>
+`
+
+	d, err := ParseMTGODeck(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := d.Section(Main).Count(); n != 24 {
+		t.Errorf("want: %d, got: %d", 24, n)
+	}
+	if n := d.Section(Sideboard).Count(); n != 2 {
+		t.Errorf("want: %d, got: %d", 2, n)
+	}
+	c := d.Section(Main).Entries[1].Card
+	if c.Name != "" || c.MtgoID != 81933 {
+		t.Errorf("want: %d, got: %q %d", 81933, c.Name, c.MtgoID)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteMTGODeck(buf, d); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != raw {
+		t.Errorf("want:\n%s\ngot:\n%s", raw, got)
+	}
+}
+
+func TestParseMTGODeckLines(t *testing.T) {
+	raw := `<?xml version="1.0" encoding="utf-8"?>
+<Deck>
+  <Extra><Cards Quantity="1" Sideboard="false" Name="Shock" Annotation="0"></Cards></Extra>
+  <Cards CatID="48394" Quantity="4" Sideboard="false" Name="Lightning Bolt" Annotation="0"></Cards>
+
+  <Cards Quantity="0" Sideboard="false" Name="Mountain" Annotation="0"></Cards>
+  <Cards CatID="27680" Quantity="2" Sideboard="true" Name="Pyroblast" Annotation="0"></Cards>
+</Deck>
+`
+	d, err := ParseMTGODeck(strings.NewReader(raw))
+	errs, ok := err.(ParseErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("want: 1 parse error, got: %v", err)
+	}
+	if want, got := 6, errs[0].Line; want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	if want, got := 7, d.Section(Sideboard).Entries[0].Line; want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
@@ -0,0 +1,132 @@
+package mtg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// A PageLayout arranges cards in a grid centered on the pages. All lengths are
+// in mm.
+type PageLayout struct {
+	// Size is one of A3, A4, A5, Letter, Legal or Tabloid.
+	Size      string
+	Landscape bool
+	// Margin is the minimum distance between the cards and the edges of the page.
+	Margin float64
+	// Gap is the distance between neighbouring cards.
+	Gap float64
+	// Columns and Rows limit the grid, 0 means as many cards as fit.
+	Columns int
+	Rows    int
+}
+
+var DefaultPageLayout = PageLayout{Size: "A4", Landscape: true, Margin: 10}
+
+// portrait page sizes
+var pageSizes = map[string][2]float64{
+	"a3":      {297, 420},
+	"a4":      {210, 297},
+	"a5":      {148, 210},
+	"letter":  {215.9, 279.4},
+	"legal":   {215.9, 355.6},
+	"tabloid": {279.4, 431.8},
+}
+
+// Flip is the edge on which a duplex printer turns the sheets.
+type Flip int
+
+const (
+	FlipLongEdge Flip = iota
+	FlipShortEdge
+)
+
+// ParseFlip parses "long" or "short".
+func ParseFlip(s string) (Flip, error) {
+	switch strings.ToLower(s) {
+	case "long":
+		return FlipLongEdge, nil
+	case "short":
+		return FlipShortEdge, nil
+	}
+	return FlipLongEdge, fmt.Errorf("unknown flip edge: %s", s)
+}
+
+// A pageGrid is a page layout computed for the size of the cards.
+type pageGrid struct {
+	orientation   string
+	pageSize      gofpdf.SizeType
+	width, height float64
+	columns, rows int
+	x, y          float64
+	gap           float64
+	cardW, cardH  float64
+}
+
+func (l PageLayout) grid() (pageGrid, error) {
+	size, ok := pageSizes[strings.ToLower(l.Size)]
+	if !ok {
+		return pageGrid{}, fmt.Errorf("unknown page size: %s", l.Size)
+	}
+	g := pageGrid{orientation: "P", width: size[0], height: size[1], gap: l.Gap, cardW: cardWidth, cardH: cardHeight}
+	if l.Landscape {
+		g.orientation = "L"
+		g.width, g.height = g.height, g.width
+	}
+	// gofpdf expects the size in portrait orientation
+	g.pageSize = gofpdf.SizeType{Wd: size[0], Ht: size[1]}
+	g.columns = fit(g.width-2*l.Margin, cardWidth, l.Gap, l.Columns)
+	g.rows = fit(g.height-2*l.Margin, cardHeight, l.Gap, l.Rows)
+	if g.columns == 0 || g.rows == 0 {
+		return pageGrid{}, fmt.Errorf("cards do not fit on a %s page with a margin of %gmm", l.Size, l.Margin)
+	}
+	g.x = (g.width - float64(g.columns)*cardWidth - float64(g.columns-1)*l.Gap) / 2
+	g.y = (g.height - float64(g.rows)*cardHeight - float64(g.rows-1)*l.Gap) / 2
+	return g, nil
+}
+
+// fit returns how many items of a size fit into a length, at most max if it is not 0.
+func fit(length float64, size float64, gap float64, max int) int {
+	n := int((length + gap) / (size + gap))
+	if n < 0 {
+		n = 0
+	}
+	if max > 0 && n > max {
+		n = max
+	}
+	return n
+}
+
+func (g pageGrid) perPage() int {
+	return g.columns * g.rows
+}
+
+// slot returns the position of the i-th card of a page.
+func (g pageGrid) slot(i int) (float64, float64) {
+	col := float64(i % g.columns)
+	row := float64(i / g.columns)
+	return g.x + col*(g.cardW+g.gap), g.y + row*(g.cardH+g.gap)
+}
+
+// backSlot returns the position on the back of a sheet that lies behind the
+// i-th card of the front once the sheet is turned on the edge, moved by the
+// offset.
+func (g pageGrid) backSlot(i int, flip Flip, offsetX, offsetY float64) (float64, float64) {
+	x, y := g.slot(i)
+	portrait := g.width < g.height
+	if (flip == FlipLongEdge) == portrait {
+		x = g.width - x - g.cardW
+	} else {
+		y = g.height - y - g.cardH
+	}
+	return x + offsetX, y + offsetY
+}
+
+func (g pageGrid) newPDF() *gofpdf.Fpdf {
+	return gofpdf.NewCustom(&gofpdf.InitType{
+		OrientationStr: g.orientation,
+		UnitStr:        "mm",
+		Size:           g.pageSize,
+	})
+}
@@ -0,0 +1,118 @@
+package mtg
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestPageLayoutGrid(t *testing.T) {
+	tests := []struct {
+		layout PageLayout
+		want   string
+	}{
+		{DefaultPageLayout, "4x2 at 22.5,17.0"},
+		{PageLayout{Size: "A4", Margin: 10}, "3x3 at 10.5,16.5"},
+		{PageLayout{Size: "Letter", Landscape: true, Margin: 10}, "4x2 at 13.7,20.0"},
+		{PageLayout{Size: "A3", Landscape: true, Margin: 10}, "6x3 at 21.0,16.5"},
+		{PageLayout{Size: "A4", Landscape: true, Margin: 10, Gap: 3}, "4x2 at 18.0,15.5"},
+		{PageLayout{Size: "A4", Landscape: true, Margin: 10, Columns: 2, Rows: 1}, "2x1 at 85.5,61.0"},
+		{PageLayout{Size: "B5", Margin: 10}, "unknown page size: B5"},
+		{PageLayout{Size: "A5", Margin: 50}, "cards do not fit on a A5 page with a margin of 50mm"},
+	}
+	for _, test := range tests {
+		g, err := test.layout.grid()
+		got := fmt.Sprintf("%dx%d at %.1f,%.1f", g.columns, g.rows, g.x, g.y)
+		if err != nil {
+			got = err.Error()
+		}
+		if test.want != got {
+			t.Errorf("want: %v, got: %v", test.want, got)
+		}
+	}
+}
+
+func TestPageGridBackSlot(t *testing.T) {
+	landscape, _ := DefaultPageLayout.grid()
+	portrait, _ := PageLayout{Size: "A4", Margin: 10}.grid()
+	tests := []struct {
+		grid    pageGrid
+		flip    Flip
+		offsetX float64
+		offsetY float64
+		slot    int
+		want    string
+	}{
+		{landscape, FlipLongEdge, 0, 0, 0, "22.5,105.0"},
+		{landscape, FlipShortEdge, 0, 0, 0, "211.5,17.0"},
+		{landscape, FlipShortEdge, 0, 0, 5, "148.5,105.0"},
+		{portrait, FlipLongEdge, 0, 0, 0, "136.5,16.5"},
+		{portrait, FlipShortEdge, 0, 0, 0, "10.5,192.5"},
+		{portrait, FlipLongEdge, 1.5, -0.5, 0, "138.0,16.0"},
+	}
+	for _, test := range tests {
+		x, y := test.grid.backSlot(test.slot, test.flip, test.offsetX, test.offsetY)
+		if got := fmt.Sprintf("%.1f,%.1f", x, y); test.want != got {
+			t.Errorf("want: %v, got: %v", test.want, got)
+		}
+	}
+}
+
+func TestLayoutDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "layout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	buf := &bytes.Buffer{}
+	if err := jpeg.Encode(buf, image.NewRGBA(image.Rect(0, 0, 63, 88)), nil); err != nil {
+		t.Fatal(err)
+	}
+	inDir := filepath.Join(dir, "cards")
+	if err := os.Mkdir(inDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 9; i++ {
+		if err := ioutil.WriteFile(filepath.Join(inDir, fmt.Sprintf("%d.jpg", i)), buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		opts  []PrinterOption
+		pages int
+	}{
+		{[]PrinterOption{Layout(DefaultPageLayout)}, 2},
+		{[]PrinterOption{Layout(PageLayout{Size: "A4", Margin: 10})}, 1},
+		{[]PrinterOption{Layout(DefaultPageLayout), Duplex(FlipLongEdge)}, 4},
+		{[]PrinterOption{Bleed(2), CutLines(), CutOutlines()}, 2},
+		{[]PrinterOption{RegistrationMarks(Silhouette), CutOutlines()}, 2},
+		{[]PrinterOption{CardBack(buf.Bytes())}, 4},
+		{[]PrinterOption{DeckNameBack(), Duplex(FlipShortEdge)}, 4},
+	}
+	for _, test := range tests {
+		outFile := filepath.Join(dir, "cards.pdf")
+		if err := LayoutDirectory("Deck", 1, inDir, outFile, test.opts...); err != nil {
+			t.Fatal(err)
+		}
+		if want, got := test.pages, countPages(t, outFile); want != got {
+			t.Errorf("want: %v, got: %v", want, got)
+		}
+	}
+}
+
+var pdfPage = regexp.MustCompile(`/Type /Page\b`)
+
+func countPages(t *testing.T, fileName string) int {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(pdfPage.FindAll(data, -1))
+}
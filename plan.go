@@ -0,0 +1,109 @@
+package mtg
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// A PlanLine is the number of copies of a card played by several decks. With
+// the Staples Binder Method only as many originals have to be owned as a single
+// deck plays at most, every deck plays proxies.
+type PlanLine struct {
+	Name  string
+	Max   int
+	Total int
+	Decks map[string]int
+}
+
+// Saved returns the number of originals that do not have to be owned.
+func (l PlanLine) Saved() int {
+	return l.Total - l.Max
+}
+
+// PlanStaples returns the cards played by more than one of the named decks
+// ordered by name. Basic lands and maybeboards are ignored.
+func PlanStaples(decks ...Deck) []PlanLine {
+	lines := map[string]*PlanLine{}
+	for _, d := range decks {
+		for _, s := range d.Sections {
+			if s.Name == Maybeboard {
+				continue
+			}
+			for _, e := range s.Entries {
+				if isBasicLandName(e.Card.Name) {
+					continue
+				}
+				key := strings.ToLower(e.Card.Name)
+				l, ok := lines[key]
+				if !ok {
+					l = &PlanLine{Name: e.Card.Name, Decks: map[string]int{}}
+					lines[key] = l
+				}
+				l.Decks[d.Name] += e.Count
+			}
+		}
+	}
+	var plan []PlanLine
+	for _, l := range lines {
+		if len(l.Decks) < 2 {
+			continue
+		}
+		for _, n := range l.Decks {
+			l.Total += n
+			if n > l.Max {
+				l.Max = n
+			}
+		}
+		plan = append(plan, *l)
+	}
+	sort.Slice(plan, func(i, j int) bool { return strings.ToLower(plan[i].Name) < strings.ToLower(plan[j].Name) })
+	return plan
+}
+
+// PlanProxies returns the proxies to print for the plan: every copy of the
+// planned cards each deck plays, labeled with the name of the deck.
+func PlanProxies(plan []PlanLine, decks ...Deck) Deck {
+	planned := map[string]bool{}
+	for _, l := range plan {
+		planned[strings.ToLower(l.Name)] = true
+	}
+	proxies := Deck{}
+	main := proxies.Section(Main)
+	for _, d := range decks {
+		for _, s := range d.Sections {
+			if s.Name == Maybeboard {
+				continue
+			}
+			for _, e := range s.Entries {
+				if planned[strings.ToLower(e.Card.Name)] {
+					e.Label = d.Name
+					main.Entries = append(main.Entries, e)
+				}
+			}
+		}
+	}
+	return proxies
+}
+
+func WritePlan(w io.Writer, plan []PlanLine) error {
+	if _, err := fmt.Fprintf(w, "%-32s %5s %5s %5s  %s\n", "Card", "Own", "Total", "Saved", "Decks"); err != nil {
+		return err
+	}
+	for _, l := range plan {
+		var names []string
+		for name := range l.Decks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var decks []string
+		for _, name := range names {
+			decks = append(decks, fmt.Sprintf("%d %s", l.Decks[name], name))
+		}
+		if _, err := fmt.Fprintf(w, "%-32s %5d %5d %5d  %s\n", l.Name, l.Max, l.Total, l.Saved(), strings.Join(decks, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
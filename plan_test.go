@@ -0,0 +1,45 @@
+package mtg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPlanStaples(t *testing.T) {
+	var decks []Deck
+	for name, text := range map[string]string{
+		"Burn":    "4 Lightning Bolt\n4 Ragavan, Nimble Pilferer\n20 Mountain\n//Sideboard\n2 Pyroblast\n",
+		"Dragons": "2 Lightning Bolt\n1 Pyroblast\n20 Mountain\n//Maybeboard\n4 Ragavan, Nimble Pilferer\n",
+	} {
+		d, err := ParseDeck(strings.NewReader(text))
+		if err != nil {
+			t.Fatal(err)
+		}
+		d.Name = name
+		decks = append(decks, d)
+	}
+
+	plan := PlanStaples(decks...)
+	buf := &bytes.Buffer{}
+	if err := WritePlan(buf, plan); err != nil {
+		t.Fatal(err)
+	}
+	want := `Card                               Own Total Saved  Decks
+Lightning Bolt                       4     6     2  4 Burn, 2 Dragons
+Pyroblast                            2     3     1  2 Burn, 1 Dragons
+`
+	if got := buf.String(); want != got {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+
+	proxies := PlanProxies(plan, decks...)
+	if want, got := 9, proxies.Count(); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	for _, e := range proxies.Section(Main).Entries {
+		if e.Label == "" {
+			t.Errorf("want: a label, got: none for %s", e.Card.Name)
+		}
+	}
+}
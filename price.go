@@ -0,0 +1,180 @@
+package mtg
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cognicraft/mtg/scryfall"
+)
+
+// A Currency is a key of the prices of scryfall cards.
+type Currency string
+
+const (
+	USD Currency = "usd"
+	EUR Currency = "eur"
+	TIX Currency = "tix"
+)
+
+// priceKey returns the key of the price of a card in the currency. There are
+// no foil prices for tix.
+func (c Currency) priceKey(foil bool) string {
+	if foil && c != TIX {
+		return string(c) + "_foil"
+	}
+	return string(c)
+}
+
+type PriceOption func(*pricer) error
+
+// Cheapest prices entries that do not request a printing by the cheapest
+// printing of the card.
+func Cheapest() PriceOption {
+	return func(p *pricer) error {
+		p.cheapest = true
+		return nil
+	}
+}
+
+type pricer struct {
+	cheapest bool
+}
+
+// A PriceLine is the price of an entry of a deck. Lines without a price are
+// missing.
+type PriceLine struct {
+	Section string
+	Entry   Entry
+	Version *Version
+	Price   float64
+	Total   float64
+	Missing bool
+}
+
+type PriceReport struct {
+	Currency Currency
+	Lines    []PriceLine
+	Total    float64
+}
+
+// Missing returns the lines without a price.
+func (r PriceReport) Missing() []PriceLine {
+	var ls []PriceLine
+	for _, l := range r.Lines {
+		if l.Missing {
+			ls = append(ls, l)
+		}
+	}
+	return ls
+}
+
+// PriceDeck prices the entries of all but the maybeboard section in the currency.
+// Entries are priced by the requested printing and finish. Cards that cannot be
+// found are reported as missing lines and a *NotFoundError. The prices are as
+// current as the card data of the client, see scryfall.MaxAge.
+func PriceDeck(client *scryfall.Client, d Deck, currency Currency, opts ...PriceOption) (PriceReport, error) {
+	p := &pricer{}
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return PriceReport{}, err
+		}
+	}
+	r := PriceReport{Currency: currency}
+	notFound := &NotFoundError{}
+	for _, s := range d.Sections {
+		if s.Name == Maybeboard {
+			continue
+		}
+		for _, e := range s.Entries {
+			l := PriceLine{Section: s.Name, Entry: e, Version: e.Card.Version, Missing: true}
+			sc := lookupPrinting(client, e.Card, scryfall.LangEnglish)
+			if sc == nil {
+				if !notFound.Cards.Contains(sameCard(e.Card)) {
+					notFound.Cards = append(notFound.Cards, e.Card)
+				}
+				r.Lines = append(r.Lines, l)
+				continue
+			}
+			key := currency.priceKey(e.Foil)
+			if p.cheapest && e.Card.Version == nil {
+				if prints, err := client.Prints(oracleID(sc)); err == nil {
+					sc = cheapestPrinting(sc, prints, key)
+				}
+			}
+			l.Version = versionFromScryfall(sc)
+			if price, ok := parsePrice(sc.Prices[key]); ok {
+				l.Price = price
+				l.Total = price * float64(e.Count)
+				l.Missing = false
+				r.Total += l.Total
+			}
+			r.Lines = append(r.Lines, l)
+		}
+	}
+	if len(notFound.Cards) > 0 {
+		return r, notFound
+	}
+	return r, nil
+}
+
+func cheapestPrinting(sc *scryfall.Card, prints []*scryfall.Card, key string) *scryfall.Card {
+	cheapest, min := sc, 0.0
+	if price, ok := parsePrice(sc.Prices[key]); ok {
+		min = price
+	} else {
+		cheapest = nil
+	}
+	for _, c := range prints {
+		if price, ok := parsePrice(c.Prices[key]); ok && (cheapest == nil || price < min) {
+			cheapest, min = c, price
+		}
+	}
+	if cheapest == nil {
+		return sc
+	}
+	return cheapest
+}
+
+func parsePrice(s string) (float64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
+
+// WritePriceReport writes the price of each line grouped by section and the
+// total of the deck.
+func WritePriceReport(w io.Writer, r PriceReport) error {
+	var b strings.Builder
+	cur := strings.ToUpper(string(r.Currency))
+	section := ""
+	for i, l := range r.Lines {
+		if l.Section != section {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "//%s\n", l.Section)
+			section = l.Section
+		}
+		e := Entry{Count: l.Entry.Count, Card: Card{Name: l.Entry.Card.Name, Version: l.Version}}
+		name := FormatEntry(e)
+		if l.Entry.Foil {
+			name += " (foil)"
+		}
+		if l.Missing {
+			fmt.Fprintf(&b, "%-48s %20s\n", name, "no price")
+			continue
+		}
+		fmt.Fprintf(&b, "%-48s %9.2f %10.2f\n", name, l.Price, l.Total)
+	}
+	fmt.Fprintf(&b, "\nTotal: %.2f %s", r.Total, cur)
+	if n := len(r.Missing()); n > 0 {
+		fmt.Fprintf(&b, " (%d without a price)", n)
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
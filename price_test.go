@@ -0,0 +1,74 @@
+package mtg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPriceDeck(t *testing.T) {
+	client, done := newTestScryfall(t)
+	defer done()
+
+	d, err := ParseDeck(strings.NewReader(`4 Lightning Bolt
+1 Black Lotus
+20 Mountain
+//Sideboard
+2 [M11:146] Lightning Bolt
+//Maybeboard
+1 Sol Ring
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Sections[1].Entries[0].Foil = true
+
+	tests := []struct {
+		name string
+		opts []PriceOption
+		want string
+	}{
+		{
+			name: "requested printings",
+			want: `//Main
+4 [M11:146] Lightning Bolt                            1.50       6.00
+1 [LEA:232] Black Lotus                                      no price
+20 [M21:272] Mountain                                 0.10       2.00
+
+//Sideboard
+2 [M11:146] Lightning Bolt (foil)                    10.00      20.00
+
+Total: 28.00 USD (1 without a price)
+`,
+		},
+		{
+			name: "cheapest printings",
+			opts: []PriceOption{Cheapest()},
+			want: `//Main
+4 [2XM:117] Lightning Bolt                            0.80       3.20
+1 [LEA:232] Black Lotus                                      no price
+20 [M21:272] Mountain                                 0.10       2.00
+
+//Sideboard
+2 [M11:146] Lightning Bolt (foil)                    10.00      20.00
+
+Total: 25.20 USD (1 without a price)
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := PriceDeck(client, d, USD, test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf := &bytes.Buffer{}
+			if err := WritePriceReport(buf, r); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); test.want != got {
+				t.Errorf("want:\n%s\ngot:\n%s", test.want, got)
+			}
+		})
+	}
+}
@@ -3,6 +3,10 @@ package mtg
 import (
 	"bytes"
 	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
 	"io"
 	"os"
 	"strings"
@@ -12,8 +16,6 @@ import (
 )
 
 const (
-	xOff       float64 = 22
-	yOff       float64 = 18
 	cardWidth  float64 = 63
 	cardHeight float64 = 88
 	labelX     float64 = 5
@@ -47,6 +49,13 @@ func NumberOfTokens(n int) PrinterOption {
 	}
 }
 
+func Sections(names ...string) PrinterOption {
+	return func(p *ProxyPrinter) error {
+		p.sections = names
+		return nil
+	}
+}
+
 func Language(lang scryfall.Lang) PrinterOption {
 	return func(p *ProxyPrinter) error {
 		p.lang = lang
@@ -54,6 +63,59 @@ func Language(lang scryfall.Lang) PrinterOption {
 	}
 }
 
+// Layout sets the page layout, the default is DefaultPageLayout.
+func Layout(l PageLayout) PrinterOption {
+	return func(p *ProxyPrinter) error {
+		p.layout = l
+		return nil
+	}
+}
+
+// Duplex writes a page with the backs of the cards after every page of fronts,
+// mirrored for printers that turn the sheets on the edge. The back faces of
+// double-faced cards and tokens are printed behind their fronts instead of on
+// pages of their own.
+func Duplex(flip Flip) PrinterOption {
+	return func(p *ProxyPrinter) error {
+		p.duplex = true
+		p.flip = flip
+		return nil
+	}
+}
+
+// BackOffset moves the backs of duplex pages by x and y mm to calibrate
+// printers that do not align both sides of a sheet.
+func BackOffset(x, y float64) PrinterOption {
+	return func(p *ProxyPrinter) error {
+		p.backOffsetX = x
+		p.backOffsetY = y
+		return nil
+	}
+}
+
+// CardBack prints an image on the backs of the cards that have no back face of
+// their own. The backs are printed duplex, by default for a printer that flips
+// the sheets on the long edge.
+func CardBack(image []byte) PrinterOption {
+	return func(p *ProxyPrinter) error {
+		p.duplex = true
+		p.cardBacks = true
+		p.cardBack = image
+		return nil
+	}
+}
+
+// DeckNameBack prints a back showing the name of the deck on the cards that have
+// no back face of their own, like CardBack.
+func DeckNameBack() PrinterOption {
+	return func(p *ProxyPrinter) error {
+		p.duplex = true
+		p.cardBacks = true
+		p.cardBack = nil
+		return nil
+	}
+}
+
 func NewProxyPrinter(client *scryfall.Client, deck Deck, opts ...PrinterOption) *ProxyPrinter {
 	p := &ProxyPrinter{
 		client:          client,
@@ -63,6 +125,7 @@ func NewProxyPrinter(client *scryfall.Client, deck Deck, opts ...PrinterOption)
 		printBackFaces:  true,
 		printTokens:     false,
 		numberOfTokens:  4,
+		layout:          DefaultPageLayout,
 	}
 	for _, opt := range opts {
 		opt(p)
@@ -78,6 +141,22 @@ type ProxyPrinter struct {
 	printBackFaces  bool
 	printTokens     bool
 	numberOfTokens  int
+	sections        []string
+	layout          PageLayout
+	duplex          bool
+	flip            Flip
+	backOffsetX     float64
+	backOffsetY     float64
+	cardBacks       bool
+	cardBack        []byte
+	cuts            cutGuides
+	bled            map[string]bledImage
+}
+
+// A bledImage is the image of a card extended by the bleed, with its size in mm.
+type bledImage struct {
+	data []byte
+	w, h float64
 }
 
 func (p *ProxyPrinter) WriteImageProxiesToFile(fileStr string) error {
@@ -85,59 +164,236 @@ func (p *ProxyPrinter) WriteImageProxiesToFile(fileStr string) error {
 	if err != nil {
 		return err
 	}
-	err = p.WriteImageProxies(pdfFile)
-	if err != nil {
-		return err
-	}
+	writeErr := p.WriteImageProxies(pdfFile)
 	err = pdfFile.Close()
 	if err != nil {
 		return err
 	}
-	return nil
+	return writeErr
 }
 
 func (p *ProxyPrinter) WriteImageProxies(w io.Writer) error {
-	pdf := gofpdf.New("L", "mm", "A4", "")
+	deck, collectErr := p.collectProxyDeck()
+	if err := p.writeImageProxies(w, deck); err != nil {
+		return err
+	}
+	return collectErr
+}
+
+// writeImageProxies writes the images of the cards of the sections to print.
+func (p *ProxyPrinter) writeImageProxies(w io.Writer, deck Deck) error {
+	g, err := p.grid()
+	if err != nil {
+		return err
+	}
+	pdf := g.newPDF()
 
 	pdf.SetFont("Arial", "", 10)
 	pdf.SetTextColor(255, 255, 255)
 
+	draw := func(card Card, x, y float64) {
+		p.drawImage(pdf, card, x, y)
+	}
+
+	p.writeSections(pdf, g, deck, draw)
+	return pdf.Output(w)
+}
+
+// drawImage draws the image of a card, rotated if it is in landscape
+// orientation.
+func (p *ProxyPrinter) drawImage(pdf *gofpdf.Fpdf, card Card, x, y float64) {
 	opt := gofpdf.ImageOptions{
-		ImageType:             "jpg",
+		ImageType:             imageType(card.ImageData),
 		AllowNegativePosition: true,
 	}
-
-	writeSection := func(cards []Card) {
-		if len(cards) == 0 {
-			// empty section
-			return
-		}
-		pdf.AddPage()
-		addCropMarks(pdf)
-		for i, card := range cards {
-			col := float64(i % 4)
-			row := float64((i % 8) / 4)
-
-			pdf.RegisterImageOptionsReader(card.Name, opt, bytes.NewBuffer(card.ImageData))
-			pdf.ImageOptions(card.Name, xOff+col*cardWidth, yOff+row*cardHeight, cardWidth, cardHeight, false, opt, 0, "")
-			if p.deck.Name != "" {
-				pdf.MoveTo(xOff+labelX+col*cardWidth, yOff+labelY+row*cardHeight)
-				pdf.CellFormat(labelWidth, labelHight, p.deck.Name, "", 0, "CM", true, 0, "")
+	if p.cuts.bleed > 0 {
+		// the images are extended once, the pdf keeps the first image of a name
+		b, ok := p.bled[card.Name]
+		if !ok {
+			if data, w, h, err := bleedImage(card.ImageData, p.cuts.bleed); err == nil {
+				b, ok = bledImage{data: data, w: w, h: h}, true
+				if p.bled == nil {
+					p.bled = map[string]bledImage{}
+				}
+				p.bled[card.Name] = b
 			}
-			if len(cards)-1 > i && i%8 == 7 {
-				pdf.AddPage()
-				addCropMarks(pdf)
+		}
+		if ok {
+			opt.ImageType = "jpg"
+			if pdf.GetImageInfo(card.Name) == nil {
+				pdf.RegisterImageOptionsReader(card.Name, opt, bytes.NewBuffer(b.data))
 			}
+			pdf.ImageOptions(card.Name, x-(b.w-cardWidth)/2, y-(b.h-cardHeight)/2, b.w, b.h, false, opt, 0, "")
+			return
 		}
 	}
+	pdf.RegisterImageOptionsReader(card.Name, opt, bytes.NewBuffer(card.ImageData))
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(card.ImageData)); err == nil && cfg.Width > cfg.Height {
+		rotate(pdf, x, y, func(x, y, w, h float64) {
+			pdf.ImageOptions(card.Name, x, y, w, h, false, opt, 0, "")
+		})
+		return
+	}
+	pdf.ImageOptions(card.Name, x, y, cardWidth, cardHeight, false, opt, 0, "")
+}
+
+// drawCardBack draws the image of the card back or a back with the name of the
+// deck.
+func (p *ProxyPrinter) drawCardBack(pdf *gofpdf.Fpdf, x, y float64) {
+	if len(p.cardBack) > 0 {
+		p.drawImage(pdf, Card{Name: "card back", ImageData: p.cardBack}, x, y)
+		return
+	}
+	fr, fg, fb := pdf.GetFillColor()
+	dr, dg, db := pdf.GetDrawColor()
+	tr, tg, tb := pdf.GetTextColor()
+	size, _ := pdf.GetFontSize()
+
+	pdf.SetFillColor(40, 32, 28)
+	pdf.RoundedRect(x, y, cardWidth, cardHeight, cornerRadius, "1234", "F")
+	pdf.SetDrawColor(200, 170, 90)
+	pdf.RoundedRect(x+3, y+3, cardWidth-2*3, cardHeight-2*3, cornerRadius, "1234", "D")
+	pdf.SetTextColor(200, 170, 90)
+	pdf.SetFont("Arial", "B", 14)
+	pdf.SetXY(x+3, y+cardHeight/2-5)
+	pdf.CellFormat(cardWidth-2*3, 10, pdf.UnicodeTranslatorFromDescriptor("")(p.deck.Name), "", 0, "CM", false, 0, "")
+
+	pdf.SetFillColor(fr, fg, fb)
+	pdf.SetDrawColor(dr, dg, db)
+	pdf.SetTextColor(tr, tg, tb)
+	pdf.SetFont("Arial", "", size)
+}
+
+// grid returns the grid of the page layout, with room for the bleed and the
+// registration marks.
+func (p *ProxyPrinter) grid() (pageGrid, error) {
+	l := p.layout
+	if l.Gap < 2*p.cuts.bleed {
+		l.Gap = 2 * p.cuts.bleed
+	}
+	g, err := l.grid()
+	if err != nil {
+		return pageGrid{}, err
+	}
+	if err := p.cuts.check(g); err != nil {
+		return pageGrid{}, err
+	}
+	return g, nil
+}
+
+// imageType returns the type of an image by its signature, jpg by default.
+func imageType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG")):
+		return "png"
+	case bytes.HasPrefix(data, []byte("GIF8")):
+		return "gif"
+	}
+	return "jpg"
+}
 
-	deck := p.collectProxyDeck()
+// rotate draws a card in landscape orientation, rotated by 90 degrees into the
+// slot at x and y.
+func rotate(pdf *gofpdf.Fpdf, x, y float64, draw func(x, y, w, h float64)) {
+	cx, cy := x+cardWidth/2, y+cardHeight/2
+	pdf.TransformBegin()
+	pdf.TransformRotate(90, cx, cy)
+	draw(cx-cardHeight/2, cy-cardWidth/2, cardHeight, cardWidth)
+	pdf.TransformEnd()
+}
+
+// landscape reports whether the text of a card is printed in landscape
+// orientation, as for split cards and the fronts of battles.
+func landscape(card Card) bool {
+	switch scryfall.Layout(card.Layout) {
+	case scryfall.LayoutSplit:
+		return true
+	case scryfall.LayoutBattle:
+		return strings.HasPrefix(card.TypeLine, "Battle")
+	}
+	return false
+}
+
+// backSections maps the sections to the sections with the back faces of their
+// cards.
+var backSections = map[string]string{FrontFaces: BackFaces, Tokens: TokenBacks}
+
+// writeSections writes the sections to print on pages of the grid. Printing
+// duplex, the back faces are written behind the front faces and tokens, and card
+// backs behind all other cards.
+func (p *ProxyPrinter) writeSections(pdf *gofpdf.Fpdf, g pageGrid, deck Deck, draw func(card Card, x, y float64)) {
 	for _, s := range deck.Sections {
-		if p.printSection(s.Name) {
-			writeSection(s.Cards)
+		if !p.printSection(s.Name) || p.duplex && (s.Name == BackFaces || s.Name == TokenBacks) {
+			continue
+		}
+		fronts := sides(s)
+		p.writePages(pdf, g, fronts, p.backSides(deck, s.Name, fronts), draw)
+	}
+}
+
+// backSides returns the sides to print behind the fronts of a section, nil if
+// not printing duplex.
+func (p *ProxyPrinter) backSides(deck Deck, section string, fronts []side) []side {
+	if !p.duplex {
+		return nil
+	}
+	backs := make([]side, len(fronts))
+	if name, ok := backSections[section]; ok {
+		for _, b := range deck.Sections {
+			if b.Name == name {
+				copy(backs, sides(b))
+			}
+		}
+	}
+	for i := range backs {
+		if p.cardBacks && backs[i].blank() && !fronts[i].blank() {
+			backs[i].cardBack = true
+		}
+	}
+	return backs
+}
+
+// writePages writes the fronts on pages of the grid with the cut guides, each
+// followed by a page of the backs if there are any. Draw draws a single card,
+// the labels are written across the middle of the cards.
+func (p *ProxyPrinter) writePages(pdf *gofpdf.Fpdf, g pageGrid, fronts []side, backs []side, draw func(card Card, x, y float64)) {
+	write := func(s side, x, y float64) {
+		if s.cardBack {
+			p.drawCardBack(pdf, x, y)
+			return
+		}
+		if s.blank() {
+			return
+		}
+		draw(s.card, x, y)
+		if s.label != "" {
+			pdf.MoveTo(x+labelX, y+labelY)
+			pdf.CellFormat(labelWidth, labelHight, s.label, "", 0, "CM", true, 0, "")
+		}
+	}
+	for start := 0; start < len(fronts); start += g.perPage() {
+		end := start + g.perPage()
+		if end > len(fronts) {
+			end = len(fronts)
+		}
+		pdf.AddPage()
+		p.cuts.addPageGuides(pdf, g)
+		for i := start; i < end; i++ {
+			x, y := g.slot(i - start)
+			write(fronts[i], x, y)
+			if !fronts[i].blank() {
+				p.cuts.addCardGuides(pdf, x, y)
+			}
+		}
+		if backs == nil {
+			continue
+		}
+		pdf.AddPage()
+		for i := start; i < end; i++ {
+			x, y := g.backSlot(i-start, p.flip, p.backOffsetX, p.backOffsetY)
+			write(backs[i], x, y)
 		}
 	}
-	return pdf.Output(w)
 }
 
 func (p *ProxyPrinter) WriteTextProxiesToFile(fileStr string) error {
@@ -145,19 +401,20 @@ func (p *ProxyPrinter) WriteTextProxiesToFile(fileStr string) error {
 	if err != nil {
 		return err
 	}
-	err = p.WriteTextProxies(pdfFile)
-	if err != nil {
-		return err
-	}
+	writeErr := p.WriteTextProxies(pdfFile)
 	err = pdfFile.Close()
 	if err != nil {
 		return err
 	}
-	return nil
+	return writeErr
 }
 
 func (p *ProxyPrinter) WriteTextProxies(w io.Writer) error {
-	pdf := gofpdf.New("L", "mm", "A4", "")
+	g, err := p.grid()
+	if err != nil {
+		return err
+	}
+	pdf := g.newPDF()
 
 	pdf.SetFont("Arial", "", 8)
 	pdf.SetTextColor(0, 0, 0)
@@ -166,84 +423,65 @@ func (p *ProxyPrinter) WriteTextProxies(w io.Writer) error {
 	rep := strings.NewReplacer("−", "-", "\n", "\n\n")
 	tr := pdf.UnicodeTranslatorFromDescriptor("")
 
-	writeSection := func(cards []Card) {
-		pdf.AddPage()
-		addCropMarks(pdf)
-		for i, card := range cards {
-			col := float64(i % 4)
-			row := float64((i % 8) / 4)
-			x := xOff + col*cardWidth
-			y := yOff + row*cardHeight
-			pdf.RoundedRect(x, y, cardWidth, cardHeight, 3, "1234", "D")
-			pdf.RoundedRect(x+2, y+2, cardWidth-2*2, cardHeight-2*2, 3, "1234", "D")
+	drawText := func(card Card, x, y, w, h float64) {
+		pdf.RoundedRect(x, y, w, h, 3, "1234", "D")
+		pdf.RoundedRect(x+2, y+2, w-2*2, h-2*2, 3, "1234", "D")
 
-			pdf.MoveTo(x+2, y+2)
-			pdf.SetFont("Arial", "B", 10)
-			pdf.CellFormat(cardWidth-2*2, 6, tr(card.Name), "", 0, "LM", false, 0, "")
-			pdf.SetFont("Arial", "", 8)
-
-			if card.ManaCost != "" {
-				pdf.MoveTo(x+2, y+2)
-				pdf.CellFormat(cardWidth-2*2, 6, tr(card.ManaCost), "", 0, "RM", false, 0, "")
-			}
+		pdf.MoveTo(x+2, y+2)
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(w-2*2, 6, tr(card.Name), "", 0, "LM", false, 0, "")
+		pdf.SetFont("Arial", "", 8)
 
-			pdf.Line(x+2, y+2+6, x+cardWidth-2, y+2+6)
+		if card.ManaCost != "" {
+			pdf.MoveTo(x+2, y+2)
+			pdf.CellFormat(w-2*2, 6, tr(card.ManaCost), "", 0, "RM", false, 0, "")
+		}
 
-			pdf.MoveTo(x+2, y+2+6)
-			pdf.CellFormat(cardWidth-2*2, 6, tr(card.TypeLine), "", 0, "LM", false, 0, "")
+		pdf.Line(x+2, y+2+6, x+w-2, y+2+6)
 
-			pdf.MoveTo(x+2, y+2+6+6+1)
-			pdf.MultiCell(cardWidth-2*2, 3.8, tr(rep.Replace(card.OracleText)), "", "LT", false)
+		pdf.MoveTo(x+2, y+2+6)
+		pdf.CellFormat(w-2*2, 6, tr(card.TypeLine), "", 0, "LM", false, 0, "")
 
-			pdf.Line(x+2, y+cardHeight-6-2, x+cardWidth-2, y+cardHeight-6-2)
-			if card.Power != "" && card.Toughness != "" {
-				pdf.MoveTo(x+cardWidth-15, y+cardHeight-6-2-2)
-				pdf.CellFormat(10, 5, fmt.Sprintf("%s / %s", card.Power, card.Toughness), "1", 0, "CM", true, 0, "")
-			}
-			if card.Loyalty != "" {
-				pdf.MoveTo(x+cardWidth-15, y+cardHeight-6-2-2)
-				pdf.CellFormat(10, 5, fmt.Sprintf("%s", card.Loyalty), "1", 0, "CM", true, 0, "")
-			}
+		pdf.MoveTo(x+2, y+2+6+6+1)
+		pdf.MultiCell(w-2*2, 3.8, tr(rep.Replace(card.OracleText)), "", "LT", false)
 
-			if p.deck.Name != "" {
-				pdf.MoveTo(xOff+labelX+col*cardWidth, yOff+labelY+row*cardHeight)
-				pdf.CellFormat(labelWidth, labelHight, p.deck.Name, "", 0, "CM", true, 0, "")
-			}
-			if len(cards)-1 > i && i%8 == 7 {
-				pdf.AddPage()
-				addCropMarks(pdf)
-			}
+		pdf.Line(x+2, y+h-6-2, x+w-2, y+h-6-2)
+		if card.Power != "" && card.Toughness != "" {
+			pdf.MoveTo(x+w-15, y+h-6-2-2)
+			pdf.CellFormat(10, 5, fmt.Sprintf("%s / %s", card.Power, card.Toughness), "1", 0, "CM", true, 0, "")
+		}
+		if card.Loyalty != "" {
+			pdf.MoveTo(x+w-15, y+h-6-2-2)
+			pdf.CellFormat(10, 5, fmt.Sprintf("%s", card.Loyalty), "1", 0, "CM", true, 0, "")
 		}
 	}
-
-	deck := p.collectProxyDeck()
-	for _, s := range deck.Sections {
-		if p.printSection(s.Name) {
-			writeSection(s.Cards)
+	draw := func(card Card, x, y float64) {
+		if landscape(card) {
+			rotate(pdf, x, y, func(x, y, w, h float64) {
+				drawText(card, x, y, w, h)
+			})
+			return
 		}
+		drawText(card, x, y, cardWidth, cardHeight)
 	}
 
-	return pdf.Output(w)
-}
-
-func (p *ProxyPrinter) collectProxyDeck() Deck {
-	versionFromCard := func(sc *scryfall.Card) *Version {
-		if sc.Set != "" && sc.CollectorNumber != "" {
-			return &Version{Set: sc.Set, CollectorNumber: sc.CollectorNumber}
-		}
-		return nil
+	deck, collectErr := p.collectProxyDeck()
+	p.writeSections(pdf, g, deck, draw)
+	if err := pdf.Output(w); err != nil {
+		return err
 	}
+	return collectErr
+}
 
+func (p *ProxyPrinter) collectProxyDeck() (Deck, error) {
 	cardFromCard := func(sc *scryfall.Card) Card {
-		c := Card{
-			Name:       sc.Name,
-			ManaCost:   sc.ManaCost,
-			TypeLine:   sc.TypeLine,
-			OracleText: sc.OracleText,
-			Power:      sc.Power,
-			Toughness:  sc.Toughness,
-			Loyalty:    sc.Loyalty,
-			Version:    versionFromCard(sc),
+		c := cardFromScryfall(sc)
+		if f := sc.Front(); f != nil && c.OracleText == "" {
+			// split, flip and adventure cards print their faces on one side
+			c.OracleText = facesText(sc)
+			if c.Power == "" && c.Toughness == "" {
+				c.Power, c.Toughness = f.Power, f.Toughness
+			}
 		}
 		if data, err := p.client.ImageByURL(sc.ImageURIs["large"]); err == nil {
 			c.ImageData = data
@@ -267,61 +505,239 @@ func (p *ProxyPrinter) collectProxyDeck() Deck {
 		return c
 	}
 
+	// faces returns the front of a card and the back if it has one of its own.
+	faces := func(sc *scryfall.Card) (Card, *Card) {
+		if !twoSided(sc) {
+			return cardFromCard(sc), nil
+		}
+		ff := cardFromFace(sc.Front())
+		ff.Version = versionFromScryfall(sc)
+		ff.Layout = string(sc.Layout)
+		bf := cardFromFace(sc.Back())
+		bf.Version = versionFromScryfall(sc)
+		bf.Layout = string(sc.Layout)
+		return ff, &bf
+	}
+
 	d := Deck{}
 	frontFaces := Section{Name: FrontFaces}
 	backFaces := Section{Name: BackFaces}
 	tokens := Section{Name: Tokens}
+	tokenBacks := Section{Name: TokenBacks}
+	notFound := &NotFoundError{}
 
-	cards := p.deck.Cards()
-	for _, card := range cards {
-		sc := p.client.CardByName(card.Name)
-		if sc == nil {
-			continue
+	var entries []Entry
+	for _, s := range p.deck.Sections {
+		if p.proxySection(s.Name) {
+			entries = append(entries, s.Entries...)
+		}
+	}
+	scs := make([]*scryfall.Card, len(entries))
+	for i, e := range entries {
+		scs[i] = p.lookupCard(e.Card)
+		if scs[i] == nil && !notFound.Cards.Contains(sameCard(e.Card)) {
+			notFound.Cards = append(notFound.Cards, e.Card)
 		}
-		if p.lang != scryfall.LangEnglish {
-			sc = p.client.CardBySetAndNumber(sc.Set, sc.CollectorNumber, p.lang)
+	}
+	melds := meldResults(entries, scs)
+
+	for i, e := range entries {
+		label := e.Label
+		if label == "" {
+			label = p.deck.Name
 		}
+		sc := scs[i]
 		if sc == nil {
 			continue
 		}
 
-		switch sc.Layout {
-		case scryfall.LayoutTransform:
-			ff := cardFromFace(sc.Front())
-			ff.Version = versionFromCard(sc)
-			frontFaces.Cards = append(frontFaces.Cards, ff)
-			bf := cardFromFace(sc.Back())
-			bf.Version = versionFromCard(sc)
-			backFaces.Cards = append(backFaces.Cards, bf)
-		default:
-			frontFaces.Cards = append(frontFaces.Cards, cardFromCard(sc))
-		}
-
-		if len(sc.AllParts) > 0 {
-			for _, part := range sc.AllParts {
-				if part.Component == "token" {
-					if tc := p.client.CardByURL(part.URI); tc != nil {
-						t := cardFromCard(tc)
-						if !tokens.Cards.Contains(CardByName(t.Name)) {
-							for i := 0; i < p.numberOfTokens; i++ {
-								tokens.Cards = append(tokens.Cards, t)
-							}
+		front, back := faces(sc)
+		backCount := e.Count
+		for _, part := range sc.AllParts {
+			switch scryfall.Component(part.Component) {
+			case scryfall.ComponentToken:
+				if tc := p.client.CardByURL(part.URI); tc != nil {
+					t, tb := faces(tc)
+					if !tokens.Cards().Contains(CardByName(t.Name)) {
+						tokens.Entries = append(tokens.Entries, Entry{Count: p.numberOfTokens, Card: t, Label: p.deck.Name})
+						if tb != nil {
+							tokenBacks.Entries = append(tokenBacks.Entries, Entry{Count: p.numberOfTokens, Card: *tb, Label: p.deck.Name})
+						} else if p.duplex {
+							tokenBacks.Entries = append(tokenBacks.Entries, Entry{Count: p.numberOfTokens})
 						}
 					}
 				}
+			case scryfall.ComponentMeldResult:
+				// the meld results are printed on the backs of the first meld part
+				m := melds[part.Name]
+				if m == nil || m.part != sc.Name || m.count == 0 {
+					continue
+				}
+				if rc := p.client.CardByURL(part.URI); rc != nil {
+					r := cardFromCard(rc)
+					back = &r
+					backCount = e.Count
+					if m.count < backCount {
+						backCount = m.count
+					}
+					m.count -= backCount
+				}
 			}
 		}
+
+		frontFaces.Entries = append(frontFaces.Entries, Entry{Count: e.Count, Card: front, Label: label})
+		blanks := e.Count
+		if back != nil {
+			backFaces.Entries = append(backFaces.Entries, Entry{Count: backCount, Card: *back, Label: label})
+			blanks -= backCount
+		}
+		if p.duplex && blanks > 0 {
+			// keeps the back faces aligned with their fronts
+			backFaces.Entries = append(backFaces.Entries, Entry{Count: blanks})
+		}
 	}
-	if len(frontFaces.Cards) > 0 {
+	if len(frontFaces.Entries) > 0 {
 		d.Sections = append(d.Sections, frontFaces)
 	}
-	if len(backFaces.Cards) > 0 {
+	if len(backFaces.Entries) > 0 {
 		d.Sections = append(d.Sections, backFaces)
 	}
-	if len(tokens.Cards) > 0 {
+	if len(tokens.Entries) > 0 {
 		d.Sections = append(d.Sections, tokens)
 	}
-	return d
+	if len(tokenBacks.Entries) > 0 {
+		d.Sections = append(d.Sections, tokenBacks)
+	}
+	if len(notFound.Cards) > 0 {
+		return d, notFound
+	}
+	return d, nil
+}
+
+// A meld is the number of meld results to print on the backs of a meld part.
+type meld struct {
+	part  string
+	count int
+}
+
+// meldResults returns the melds by the names of the meld results. A result is
+// printed once for every pair of its meld parts in the entries, on the backs of
+// the part that comes first.
+func meldResults(entries []Entry, scs []*scryfall.Card) map[string]*meld {
+	melds := map[string]*meld{}
+	counts := map[string]map[string]int{}
+	for i, sc := range scs {
+		if sc == nil {
+			continue
+		}
+		for _, part := range sc.AllParts {
+			if scryfall.Component(part.Component) != scryfall.ComponentMeldResult || strings.EqualFold(part.Name, sc.Name) {
+				continue
+			}
+			if melds[part.Name] == nil {
+				melds[part.Name] = &meld{part: sc.Name}
+				counts[part.Name] = map[string]int{}
+			}
+			counts[part.Name][sc.Name] += entries[i].Count
+		}
+	}
+	for name, m := range melds {
+		if len(counts[name]) < 2 {
+			continue
+		}
+		m.count = -1
+		for _, n := range counts[name] {
+			if m.count < 0 || n < m.count {
+				m.count = n
+			}
+		}
+	}
+	return melds
+}
+
+// twoSided reports whether both faces of a card have an image of their own. The
+// faces of split, flip and adventure cards share one side, meld parts have the
+// meld result on their backs.
+func twoSided(sc *scryfall.Card) bool {
+	switch sc.Layout {
+	case scryfall.LayoutTransform, scryfall.LayoutModalDFC, scryfall.LayoutBattle,
+		scryfall.LayoutDoubleFacedToken, scryfall.LayoutReversibleCard, scryfall.LayoutArtSeries:
+		return sc.Front() != nil && sc.Back() != nil
+	}
+	return false
+}
+
+// facesText returns the text of all faces of a card.
+func facesText(sc *scryfall.Card) string {
+	var ts []string
+	for _, f := range sc.CardFaces {
+		t := f.Name
+		if f.ManaCost != "" {
+			t += " " + f.ManaCost
+		}
+		t += "\n" + f.TypeLine
+		if f.OracleText != "" {
+			t += "\n" + f.OracleText
+		}
+		ts = append(ts, t)
+	}
+	return strings.Join(ts, "\n")
+}
+
+// A side is what is printed on one side of a card, a card or a card back.
+type side struct {
+	card     Card
+	label    string
+	cardBack bool
+}
+
+// blank reports whether nothing is printed on the side.
+func (s side) blank() bool {
+	return !s.cardBack && s.card.Name == "" && len(s.card.ImageData) == 0
+}
+
+// sides expands the entries of a section into the sides to print.
+func sides(s Section) []side {
+	var ss []side
+	for _, e := range s.Entries {
+		for i := 0; i < e.Count; i++ {
+			ss = append(ss, side{card: e.Card, label: e.Label})
+		}
+	}
+	return ss
+}
+
+func (p *ProxyPrinter) lookupCard(card Card) *scryfall.Card {
+	return lookupPrinting(p.client, card, p.lang)
+}
+
+// lookupPrinting looks up the requested printing of a card in a language.
+func lookupPrinting(client *scryfall.Client, card Card, lang scryfall.Lang) *scryfall.Card {
+	var sc *scryfall.Card
+	switch {
+	case card.Version != nil && card.Version.CollectorNumber != "":
+		return client.CardBySetAndNumber(strings.ToLower(card.Version.Set), card.Version.CollectorNumber, lang)
+	case card.Version != nil && card.Version.Set != "":
+		sc = client.CardByNameAndSet(card.Name, strings.ToLower(card.Version.Set))
+	default:
+		sc = client.CardByName(card.Name)
+	}
+	if sc != nil && lang != scryfall.LangEnglish {
+		sc = client.CardBySetAndNumber(sc.Set, sc.CollectorNumber, lang)
+	}
+	return sc
+}
+
+func (p *ProxyPrinter) proxySection(name string) bool {
+	if len(p.sections) == 0 {
+		return name != Maybeboard
+	}
+	for _, s := range p.sections {
+		if n, ok := SectionName(s); ok && n == name || s == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *ProxyPrinter) printSection(name string) bool {
@@ -330,26 +746,36 @@ func (p *ProxyPrinter) printSection(name string) bool {
 		return p.printFrontFaces
 	case BackFaces:
 		return p.printBackFaces
-	case Tokens:
+	case Tokens, TokenBacks:
 		return p.printTokens
 	}
 	return true
 }
 
-func addCropMarks(pdf *gofpdf.Fpdf) {
-	pageWidth, pageHeight := pdf.GetPageSize()
-	for i := 0; i <= 4; i++ {
-		pdf.Line(xOff+float64(i)*cardWidth, 0, xOff+float64(i)*cardWidth, 10)
-		pdf.Line(xOff+float64(i)*cardWidth, pageHeight-10, xOff+float64(i)*cardWidth, pageHeight)
-	}
-	for i := 0; i <= 2; i++ {
-		pdf.Line(0, yOff+float64(i)*cardHeight, 10, yOff+float64(i)*cardHeight)
-		pdf.Line(pageWidth-10, yOff+float64(i)*cardHeight, pageWidth, yOff+float64(i)*cardHeight)
+// A NotFoundError reports the cards, or the requested printings of cards, that could
+// not be found. Proxies for all other cards have been written nevertheless.
+type NotFoundError struct {
+	Cards Cards
+}
+
+func (e *NotFoundError) Error() string {
+	var names []string
+	for _, c := range e.Cards {
+		name := c.Name
+		if name == "" && c.MtgoID != 0 {
+			name = fmt.Sprintf("MTGO #%d", c.MtgoID)
+		}
+		if c.Version != nil {
+			name = fmt.Sprintf("%s [%s]", name, c.Version)
+		}
+		names = append(names, name)
 	}
+	return fmt.Sprintf("cards not found: %s", strings.Join(names, ", "))
 }
 
 const (
 	FrontFaces = "FrontFaces"
 	BackFaces  = "BackFaces"
 	Tokens     = "Tokens"
+	TokenBacks = "TokenBacks"
 )
@@ -0,0 +1,248 @@
+package mtg
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func multiFacedDeck() Deck {
+	names := []string{
+		"Valakut Awakening",
+		"Akki Lavarunner",
+		"Fire // Ice",
+		"Bonecrusher Giant",
+		"Bruna, the Fading Light",
+		"Gisela, the Broken Blade",
+		"Invasion of Zendikar",
+		"Delver of Secrets",
+		"Tireless Tracker",
+	}
+	d := Deck{Name: "Faces"}
+	main := d.Section(Main)
+	for i, name := range names {
+		count := 1
+		if i == 0 {
+			count = 2
+		}
+		main.Entries = append(main.Entries, Entry{Count: count, Card: Card{Name: name}})
+	}
+	return d
+}
+
+func TestCollectProxyDeck(t *testing.T) {
+	client, done := newTestScryfall(t)
+	defer done()
+
+	tests := []struct {
+		opts []PrinterOption
+		want string
+	}{
+		{
+			opts: nil,
+			want: `//FrontFaces
+2 Valakut Awakening
+1 Akki Lavarunner // Tok-Tok, Volcano Born
+1 Fire // Ice (landscape)
+1 Bonecrusher Giant // Stomp
+1 Bruna, the Fading Light
+1 Gisela, the Broken Blade
+1 Invasion of Zendikar (landscape)
+1 Delver of Secrets
+1 Tireless Tracker
+//BackFaces
+2 Valakut Stoneforge
+1 Brisela, Voice of Nightmares
+1 Awakened Skyclave
+1 Insectile Aberration
+//Tokens
+4 Clue
+//TokenBacks
+4 Food
+`,
+		},
+		{
+			opts: []PrinterOption{Duplex(FlipLongEdge)},
+			want: `//FrontFaces
+2 Valakut Awakening
+1 Akki Lavarunner // Tok-Tok, Volcano Born
+1 Fire // Ice (landscape)
+1 Bonecrusher Giant // Stomp
+1 Bruna, the Fading Light
+1 Gisela, the Broken Blade
+1 Invasion of Zendikar (landscape)
+1 Delver of Secrets
+1 Tireless Tracker
+//BackFaces
+2 Valakut Stoneforge
+1
+1
+1
+1 Brisela, Voice of Nightmares
+1
+1 Awakened Skyclave
+1 Insectile Aberration
+1
+//Tokens
+4 Clue
+//TokenBacks
+4 Food
+`,
+		},
+	}
+	for _, test := range tests {
+		d, err := NewProxyPrinter(client, multiFacedDeck(), test.opts...).collectProxyDeck()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := proxyDeckText(t, d); test.want != got {
+			t.Errorf("want: %v, got: %v", test.want, got)
+		}
+	}
+}
+
+func TestCollectMeldResults(t *testing.T) {
+	client, done := newTestScryfall(t)
+	defer done()
+
+	tests := []struct {
+		entries []Entry
+		want    string
+	}{
+		{
+			entries: []Entry{{Count: 2, Card: Card{Name: "Bruna, the Fading Light"}}, {Count: 3, Card: Card{Name: "Gisela, the Broken Blade"}}},
+			want:    "//BackFaces\n2 Brisela, Voice of Nightmares\n3\n",
+		},
+		{
+			entries: []Entry{{Count: 3, Card: Card{Name: "Gisela, the Broken Blade"}}, {Count: 1, Card: Card{Name: "Bruna, the Fading Light"}}},
+			want:    "//BackFaces\n1 Brisela, Voice of Nightmares\n2\n1\n",
+		},
+		{
+			entries: []Entry{{Count: 2, Card: Card{Name: "Bruna, the Fading Light"}}},
+			want:    "//BackFaces\n2\n",
+		},
+	}
+	for _, test := range tests {
+		d := Deck{Sections: []Section{{Name: Main, Entries: test.entries}}}
+		d, err := NewProxyPrinter(client, d, Duplex(FlipLongEdge)).collectProxyDeck()
+		if err != nil {
+			t.Fatal(err)
+		}
+		d.Sections = []Section{*d.Section(BackFaces)}
+		if got := proxyDeckText(t, d); test.want != got {
+			t.Errorf("want: %v, got: %v", test.want, got)
+		}
+	}
+}
+
+// proxyDeckText lists the sections of a proxy deck and reports cards without an
+// image.
+func proxyDeckText(t *testing.T, d Deck) string {
+	buf := &bytes.Buffer{}
+	for _, s := range d.Sections {
+		fmt.Fprintf(buf, "//%s\n", s.Name)
+		for _, e := range s.Entries {
+			line := strings.TrimSpace(fmt.Sprintf("%d %s", e.Count, e.Card.Name))
+			if landscape(e.Card) {
+				line += " (landscape)"
+			}
+			fmt.Fprintln(buf, line)
+			if e.Card.Name != "" && len(e.Card.ImageData) == 0 {
+				t.Errorf("no image: %s", e.Card.Name)
+			}
+		}
+	}
+	return buf.String()
+}
+
+func TestFacesText(t *testing.T) {
+	client, done := newTestScryfall(t)
+	defer done()
+
+	sc := client.CardByName("Bonecrusher Giant")
+	if sc == nil {
+		t.Fatal("card not found")
+	}
+	want := strings.Join([]string{
+		"Bonecrusher Giant {2}{R}",
+		"Creature — Giant",
+		"Whenever Bonecrusher Giant becomes the target of a spell, Bonecrusher Giant deals 2 damage to that spell's controller.",
+		"Stomp {1}{R}",
+		"Instant — Adventure",
+		"Damage can't be prevented this turn. Stomp deals 2 damage to any target.",
+	}, "\n")
+	if got := facesText(sc); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestWriteProxiesDuplex(t *testing.T) {
+	client, done := newTestScryfall(t)
+	defer done()
+
+	p := NewProxyPrinter(client, multiFacedDeck(), Duplex(FlipShortEdge), BackOffset(0.5, -0.5))
+	for _, write := range []func(*bytes.Buffer) error{
+		func(buf *bytes.Buffer) error { return p.WriteImageProxies(buf) },
+		func(buf *bytes.Buffer) error { return p.WriteTextProxies(buf) },
+	} {
+		buf := &bytes.Buffer{}
+		if err := write(buf); err != nil {
+			t.Fatal(err)
+		}
+		// 10 fronts on 2 pages, each followed by a page of backs
+		if want, got := 4, len(pdfPage.FindAll(buf.Bytes(), -1)); want != got {
+			t.Errorf("want: %v, got: %v", want, got)
+		}
+	}
+}
+
+func TestBackSides(t *testing.T) {
+	d := Deck{Sections: []Section{
+		{Name: FrontFaces, Entries: []Entry{
+			{Count: 2, Card: Card{Name: "Delver of Secrets"}},
+			{Count: 1, Card: Card{Name: "Lightning Bolt"}},
+		}},
+		{Name: BackFaces, Entries: []Entry{
+			{Count: 2, Card: Card{Name: "Insectile Aberration"}},
+			{Count: 1},
+		}},
+		{Name: Tokens, Entries: []Entry{
+			{Count: 1, Card: Card{Name: "Zombie"}},
+			{Count: 1, Card: Card{Name: "Clue"}},
+		}},
+		{Name: TokenBacks, Entries: []Entry{
+			{Count: 1},
+			{Count: 1, Card: Card{Name: "Food"}},
+		}},
+	}}
+
+	tests := []struct {
+		opts    []PrinterOption
+		section string
+		want    string
+	}{
+		{nil, FrontFaces, ""},
+		{[]PrinterOption{Duplex(FlipLongEdge)}, FrontFaces, "Insectile Aberration, Insectile Aberration, -"},
+		{[]PrinterOption{Duplex(FlipLongEdge)}, Tokens, "-, Food"},
+		{[]PrinterOption{DeckNameBack()}, FrontFaces, "Insectile Aberration, Insectile Aberration, back"},
+		{[]PrinterOption{CardBack([]byte("jpg"))}, Tokens, "back, Food"},
+	}
+	for _, test := range tests {
+		p := NewProxyPrinter(nil, d, test.opts...)
+		var names []string
+		for _, b := range p.backSides(d, test.section, sides(*d.Section(test.section))) {
+			switch {
+			case b.cardBack:
+				names = append(names, "back")
+			case b.blank():
+				names = append(names, "-")
+			default:
+				names = append(names, b.card.Name)
+			}
+		}
+		if got := strings.Join(names, ", "); test.want != got {
+			t.Errorf("want: %v, got: %v", test.want, got)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package mtg
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cognicraft/mtg/scryfall"
+)
+
+type ResolutionStatus string
+
+const (
+	// The name was found as it is.
+	Resolved ResolutionStatus = "resolved"
+	// The name was found by a fuzzy match and differs from the canonical name.
+	Corrected ResolutionStatus = "corrected"
+	// The name matched too many cards.
+	Ambiguous ResolutionStatus = "ambiguous"
+	NotFound  ResolutionStatus = "not_found"
+)
+
+// A Resolution reports how a single entry of a deck was resolved.
+type Resolution struct {
+	Section    string
+	Entry      Entry
+	Status     ResolutionStatus
+	Name       string
+	OracleID   string
+	Candidates []string
+	Err        error
+}
+
+func (r Resolution) String() string {
+	line := ""
+	if r.Entry.Line > 0 {
+		line = fmt.Sprintf("line %d: ", r.Entry.Line)
+	}
+	switch r.Status {
+	case Corrected:
+		return fmt.Sprintf("%s%q -> %q", line, r.Entry.Card.Name, r.Name)
+	case Ambiguous:
+		if len(r.Candidates) > 0 {
+			return fmt.Sprintf("%s%q is ambiguous, did you mean: %s", line, r.Entry.Card.Name, strings.Join(r.Candidates, ", "))
+		}
+		return fmt.Sprintf("%s%q is ambiguous", line, r.Entry.Card.Name)
+	case NotFound:
+		return fmt.Sprintf("%s%q not found", line, r.Entry.Card.Name)
+	}
+	return fmt.Sprintf("%s%q", line, r.Entry.Card.Name)
+}
+
+type ResolverOption func(*Resolver) error
+
+// ResolvePrintings fills in the printing of entries without a collector number.
+func ResolvePrintings() ResolverOption {
+	return func(r *Resolver) error {
+		r.printings = true
+		return nil
+	}
+}
+
+func NewResolver(client *scryfall.Client, opts ...ResolverOption) *Resolver {
+	r := &Resolver{
+		client: client,
+		cards:  map[string]*scryfall.Card{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// A Resolver looks up the cards of a deck on scryfall. Cards that are resolved
+// are enriched with their oracle information.
+type Resolver struct {
+	client    *scryfall.Client
+	printings bool
+	cards     map[string]*scryfall.Card
+}
+
+// Resolve returns a copy of the deck using canonical card names and oracle IDs.
+// Entries that cannot be resolved are kept as they are.
+func (r *Resolver) Resolve(d Deck) (Deck, []Resolution) {
+	var rs []Resolution
+	sections := make([]Section, len(d.Sections))
+	for i, s := range d.Sections {
+		sections[i] = Section{Name: s.Name}
+		for _, e := range s.Entries {
+			res, sc := r.resolveEntry(e)
+			res.Section = s.Name
+			rs = append(rs, res)
+			if sc != nil {
+				c := cardFromScryfall(sc)
+				c.Name = res.Name
+				if !r.printings {
+					c.Version = e.Card.Version
+					c.MtgoID = e.Card.MtgoID
+				}
+				e.Card = c
+			}
+			sections[i].Entries = append(sections[i].Entries, e)
+		}
+	}
+	d.Sections = sections
+	return d, rs
+}
+
+// Card returns the scryfall card an already resolved card was resolved to.
+func (r *Resolver) Card(c Card) *scryfall.Card {
+	if c.OracleID == "" {
+		return nil
+	}
+	return r.cards[c.OracleID]
+}
+
+func (r *Resolver) resolveEntry(e Entry) (Resolution, *scryfall.Card) {
+	res := Resolution{Entry: e}
+	name := e.Card.Name
+	var sc *scryfall.Card
+	var err error
+	switch v := e.Card.Version; {
+	case v != nil && v.CollectorNumber != "":
+		sc = r.client.CardBySetAndNumber(strings.ToLower(v.Set), v.CollectorNumber, scryfall.LangEnglish)
+	case v != nil && v.Set != "":
+		sc = r.client.CardByNameAndSet(name, strings.ToLower(v.Set))
+	default:
+		sc, err = r.client.LookupCardByName(name)
+	}
+	if sc == nil {
+		res.Status = NotFound
+		res.Err = err
+		if apiErr, ok := err.(*scryfall.Error); ok && apiErr.IsAmbiguous() {
+			res.Status = Ambiguous
+			res.Candidates, _ = r.client.Autocomplete(name)
+		}
+		return res, nil
+	}
+	id := oracleID(sc)
+	if _, ok := r.cards[id]; !ok && id != "" {
+		r.cards[id] = sc
+	}
+	res.OracleID = id
+	res.Name = canonicalName(name, sc)
+	res.Status = Resolved
+	if res.Name != name {
+		res.Status = Corrected
+	}
+	return res, sc
+}
+
+// canonicalName returns the name of the card, but keeps the name of the front
+// face of multi-faced cards if that is what was asked for.
+func canonicalName(name string, sc *scryfall.Card) string {
+	if f := sc.Front(); f != nil && f.Name == name {
+		return name
+	}
+	if f := sc.Front(); f != nil && strings.EqualFold(f.Name, name) {
+		return f.Name
+	}
+	return sc.Name
+}
+
+// WriteResolutions writes the entries that have not been resolved as they are.
+func WriteResolutions(w io.Writer, rs []Resolution) error {
+	for _, r := range rs {
+		if r.Status == Resolved {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s\n", r.Status, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func versionFromScryfall(sc *scryfall.Card) *Version {
+	if sc.Set != "" && sc.CollectorNumber != "" {
+		return &Version{Set: strings.ToUpper(sc.Set), CollectorNumber: sc.CollectorNumber}
+	}
+	return nil
+}
+
+// oracleID returns the Oracle ID of a card, or of its front face for reversible
+// cards that only have Oracle IDs on their faces.
+func oracleID(sc *scryfall.Card) string {
+	if f := sc.Front(); sc.OracleID == "" && f != nil {
+		return f.OracleID
+	}
+	return sc.OracleID
+}
+
+func cardFromScryfall(sc *scryfall.Card) Card {
+	c := Card{
+		Name:         sc.Name,
+		ManaCost:     sc.ManaCost,
+		CMC:          sc.CMC,
+		Colors:       sc.Colors,
+		TypeLine:     sc.TypeLine,
+		OracleText:   sc.OracleText,
+		Power:        sc.Power,
+		Toughness:    sc.Toughness,
+		Loyalty:      sc.Loyalty,
+		ProducedMana: sc.ProducedMana,
+		Version:      versionFromScryfall(sc),
+		MtgoID:       sc.MtgoID,
+		OracleID:     oracleID(sc),
+		Layout:       string(sc.Layout),
+	}
+	if f := sc.Front(); f != nil {
+		// multi-faced cards are cast by their front face
+		if c.ManaCost == "" {
+			c.ManaCost = f.ManaCost
+		}
+		if len(c.Colors) == 0 {
+			for _, color := range f.Colors {
+				c.Colors = append(c.Colors, string(color))
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,80 @@
+package mtg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolver(t *testing.T) {
+	client, done := newTestScryfall(t)
+	defer done()
+
+	raw := `4 Lightnig Bolt
+4 Delver of Secrets
+1 Jace
+1 Xyzzy
+1 [M11:146] Lightning Bolt
+`
+	d, err := ParseDeck(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rd, rs := NewResolver(client).Resolve(d)
+	statuses := []ResolutionStatus{Corrected, Resolved, Ambiguous, NotFound, Resolved}
+	if len(rs) != len(statuses) {
+		t.Fatalf("want: %d, got: %d", len(statuses), len(rs))
+	}
+	for i, w := range statuses {
+		if rs[i].Status != w {
+			t.Errorf("%s: want: %s, got: %s", rs[i].Entry.Card.Name, w, rs[i].Status)
+		}
+	}
+	if len(rs[2].Candidates) != 2 {
+		t.Errorf("want: %d, got: %d", 2, len(rs[2].Candidates))
+	}
+
+	es := rd.Section(Main).Entries
+	if es[0].Card.Name != "Lightning Bolt" || es[0].Card.OracleID != "o-bolt" || es[0].Card.Version != nil {
+		t.Errorf("unexpected card: %+v", es[0].Card)
+	}
+	if es[1].Card.Name != "Delver of Secrets" || es[1].Card.OracleID != "o-delver" {
+		t.Errorf("unexpected card: %+v", es[1].Card)
+	}
+	if es[2].Card.Name != "Jace" || es[2].Card.OracleID != "" {
+		t.Errorf("unexpected card: %+v", es[2].Card)
+	}
+	if v := es[4].Card.Version; v == nil || v.Set != "M11" {
+		t.Errorf("want: %s, got: %v", "M11", v)
+	}
+
+	rd, _ = NewResolver(client, ResolvePrintings()).Resolve(d)
+	want := Version{Set: "M11", CollectorNumber: "146"}
+	if v := rd.Section(Main).Entries[0].Card.Version; v == nil || *v != want {
+		t.Errorf("want: %v, got: %v", want, v)
+	}
+}
+
+func TestResolverCardWithoutOracleID(t *testing.T) {
+	client, done := newTestScryfall(t)
+	defer done()
+
+	d, err := ParseDeck(strings.NewReader("1 Zndrsplt, Eye of Wisdom\n1 Xyzzy\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewResolver(client)
+	rd, rs := r.Resolve(d)
+	if rs[0].Status != Resolved {
+		t.Errorf("want: %s, got: %s", Resolved, rs[0].Status)
+	}
+	es := rd.Section(Main).Entries
+	if want, got := "o-zndrsplt", es[0].Card.OracleID; want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	if sc := r.Card(es[0].Card); sc == nil || sc.ID != "zn1" {
+		t.Errorf("want: %v, got: %v", "zn1", sc)
+	}
+	if sc := r.Card(es[1].Card); sc != nil {
+		t.Errorf("want: %v, got: %s", nil, sc.Name)
+	}
+}
@@ -104,6 +104,9 @@ type Card struct {
 	// The Oracle text for this card, if any.
 	OracleText string `json:"oracle_text,omitempty"`
 
+	// Colors of mana that this card could produce.
+	ProducedMana []string `json:"produced_mana,omitempty"`
+
 	// True if this card is oversized.
 	Oversized bool `json:"oversized"`
 
@@ -292,6 +295,10 @@ type CardFace struct {
 	// A content type for this object, always card_face.
 	Object string `json:"object"`
 
+	// OracleID is the Oracle ID of this face, if the card has no Oracle ID of
+	// its own, as for reversible cards.
+	OracleID string `json:"oracle_id,omitempty"`
+
 	// OracleText is the Oracle text for this face, if any.
 	OracleText string `json:"oracle_text,omitempty"`
 
@@ -372,9 +379,16 @@ const (
 	LayoutSplit            Layout = "split"
 	LayoutFlip             Layout = "flip"
 	LayoutTransform        Layout = "transform"
+	LayoutModalDFC         Layout = "modal_dfc"
 	LayoutMeld             Layout = "meld"
 	LayoutLeveler          Layout = "leveler"
+	LayoutClass            Layout = "class"
+	LayoutCase             Layout = "case"
 	LayoutSaga             Layout = "saga"
+	LayoutAdventure        Layout = "adventure"
+	LayoutMutate           Layout = "mutate"
+	LayoutPrototype        Layout = "prototype"
+	LayoutBattle           Layout = "battle"
 	LayoutPlanar           Layout = "planar"
 	LayoutScheme           Layout = "scheme"
 	LayoutVanguard         Layout = "vanguard"
@@ -383,6 +397,8 @@ const (
 	LayoutEmblem           Layout = "emblem"
 	LayoutAugment          Layout = "augment"
 	LayoutHost             Layout = "host"
+	LayoutArtSeries        Layout = "art_series"
+	LayoutReversibleCard   Layout = "reversible_card"
 )
 
 type Legality string
@@ -0,0 +1,17 @@
+package scryfall
+
+// A Catalog object contains an array of Magic datapoints (words, card values, etc).
+// Catalog objects are provided by the API as aids for building other Magic software
+// and understanding possible values for a field on Card objects.
+type Catalog struct {
+	Object string `json:"object"`
+
+	// A link to the current catalog on Scryfall’s API.
+	URI string `json:"uri"`
+
+	// The number of items in the data array.
+	TotalValues int `json:"total_values"`
+
+	// An array of datapoints, as strings.
+	Data []string `json:"data"`
+}
@@ -47,6 +47,23 @@ func Cache(cache *archive.Archive) ClientOption {
 	}
 }
 
+func BaseURL(url string) ClientOption {
+	return func(c *Client) error {
+		c.baseURL = url
+		return nil
+	}
+}
+
+// MaxAge refetches cached card data that is older than the duration, e.g. to
+// keep prices current. Expired card data is still used if it cannot be
+// refetched. By default cached card data never expires.
+func MaxAge(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.maxAge = d
+		return nil
+	}
+}
+
 func Debug() ClientOption {
 	return func(c *Client) error {
 		c.logf = func(format string, args ...interface{}) {
@@ -59,6 +76,7 @@ func Debug() ClientOption {
 type Client struct {
 	baseURL    string
 	cache      *archive.Archive
+	maxAge     time.Duration
 	logf       func(string, ...interface{})
 	delay      time.Duration
 	lastAccess time.Time
@@ -66,24 +84,55 @@ type Client struct {
 }
 
 func (c *Client) CardByName(name string) *Card {
+	card, _ := c.LookupCardByName(name)
+	return card
+}
+
+// LookupCardByName is like CardByName but reports why a card could not be found.
+// Errors reported by scryfall, e.g. for ambiguous names, are of type *Error.
+func (c *Client) LookupCardByName(name string) (*Card, error) {
 	c.logf("[DEBUG] CardByName(%q)", name)
 
 	url := c.urlCardByName(name)
 
 	card := Card{}
-	if err := archive.LoadJSON(c.cache, url, &card); err == nil {
-		c.logf("[DEBUG]   retrieved from cache")
-		return &card
+	if err := c.getJSON(url, &card); err != nil {
+		return nil, err
 	}
+	return &card, nil
+}
+
+// Autocomplete returns up to 20 full card names that could be autocompletions of the given string.
+func (c *Client) Autocomplete(q string) ([]string, error) {
+	c.logf("[DEBUG] Autocomplete(%q)", q)
 
-	err := c.doGetJSON(url, &card)
+	cat := Catalog{}
+	err := c.doGetJSON(c.urlAutocomplete(q), &cat)
 	if err != nil {
 		c.logf("[ERROR]   %v", err)
-		return nil
+		return nil, err
 	}
-	c.cache.Store(archive.GenericJSON(url, card))
-	c.logf("[DEBUG]   retrieved from scryfall")
-	return &card
+	return cat.Data, nil
+}
+
+// Prints returns all printings of the card with the oracle id.
+func (c *Client) Prints(oracleID string) ([]*Card, error) {
+	c.logf("[DEBUG] Prints(%q)", oracleID)
+
+	var cards []*Card
+	url := c.urlPrints(oracleID)
+	for url != "" {
+		list := List{}
+		if err := c.getJSON(url, &list); err != nil {
+			return nil, err
+		}
+		cards = append(cards, list.Cards()...)
+		url = ""
+		if list.HasMore {
+			url = list.NextPage
+		}
+	}
+	return cards, nil
 }
 
 func (c *Client) CardBySetAndNumber(set string, number string, lang Lang) *Card {
@@ -92,35 +141,41 @@ func (c *Client) CardBySetAndNumber(set string, number string, lang Lang) *Card
 	url := c.urlCardBySetAndNumber(set, number, lang)
 
 	card := Card{}
-	if err := archive.LoadJSON(c.cache, url, &card); err == nil {
-		c.logf("[DEBUG]   retrieved from cache")
-		return &card
+	if err := c.getJSON(url, &card); err != nil {
+		return nil
 	}
+	return &card
+}
 
-	err := c.doGetJSON(url, &card)
-	if err != nil {
-		c.logf("[ERROR]   %v", err)
+func (c *Client) CardByNameAndSet(name string, set string) *Card {
+	c.logf("[DEBUG] CardByNameAndSet(%q, %q)", name, set)
+
+	url := c.urlCardByNameAndSet(name, set)
+
+	card := Card{}
+	if err := c.getJSON(url, &card); err != nil {
 		return nil
 	}
-	c.cache.Store(archive.GenericJSON(url, card))
-	c.logf("[DEBUG]   retrieved from scryfall")
 	return &card
 }
 
-func (c *Client) CardByURL(url string) *Card {
+func (c *Client) CardByMtgoID(id int) *Card {
+	c.logf("[DEBUG] CardByMtgoID(%d)", id)
+
+	url := c.urlCardByMtgoID(id)
+
 	card := Card{}
-	if err := archive.LoadJSON(c.cache, url, &card); err == nil {
-		c.logf("[DEBUG]   retrieved from cache")
-		return &card
+	if err := c.getJSON(url, &card); err != nil {
+		return nil
 	}
+	return &card
+}
 
-	err := c.doGetJSON(url, &card)
-	if err != nil {
-		c.logf("[ERROR]   %v", err)
+func (c *Client) CardByURL(url string) *Card {
+	card := Card{}
+	if err := c.getJSON(url, &card); err != nil {
 		return nil
 	}
-	c.cache.Store(archive.GenericJSON(url, card))
-	c.logf("[DEBUG]   retrieved from scryfall")
 	return &card
 }
 
@@ -141,6 +196,37 @@ func (s *Client) ImageByURL(url string) ([]byte, error) {
 	return data, nil
 }
 
+// getJSON gets JSON from the cache, or from scryfall if it is not cached or has
+// expired. Expired JSON is used if it cannot be refetched.
+func (c *Client) getJSON(url string, v interface{}) error {
+	res, err := c.cache.Load(url)
+	cached := err == nil
+	if cached && !c.expired(res) {
+		c.logf("[DEBUG]   retrieved from cache")
+		return json.Unmarshal(res.Data, v)
+	}
+	if err := c.doGetJSON(url, v); err != nil {
+		c.logf("[ERROR]   %v", err)
+		if cached {
+			c.logf("[DEBUG]   retrieved expired from cache")
+			return json.Unmarshal(res.Data, v)
+		}
+		return err
+	}
+	c.cache.Store(archive.GenericJSON(url, v))
+	c.logf("[DEBUG]   retrieved from scryfall")
+	return nil
+}
+
+// expired reports whether a cached resource is older than the max age.
+func (c *Client) expired(res archive.Resource) bool {
+	if c.maxAge <= 0 {
+		return false
+	}
+	modified, err := time.Parse(time.RFC3339, res.Attributes[archive.AttributeLastModified])
+	return err != nil || time.Since(modified) > c.maxAge
+}
+
 func (c *Client) doGetJSON(url string, v interface{}) error {
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
@@ -152,6 +238,10 @@ func (c *Client) doGetJSON(url string, v interface{}) error {
 	}
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
+		apiErr := &Error{}
+		if err := json.NewDecoder(res.Body).Decode(apiErr); err == nil && apiErr.Object == "error" {
+			return apiErr
+		}
 		return fmt.Errorf("bad status: %s - %s", url, res.Status)
 	}
 	return json.NewDecoder(res.Body).Decode(v)
@@ -190,10 +280,26 @@ func (s *Client) urlCardByName(name string) string {
 	return fmt.Sprintf("%s/cards/named?fuzzy=%s", s.baseURL, url.QueryEscape(name))
 }
 
+func (s *Client) urlAutocomplete(q string) string {
+	return fmt.Sprintf("%s/cards/autocomplete?q=%s", s.baseURL, url.QueryEscape(q))
+}
+
+func (s *Client) urlCardByNameAndSet(name string, set string) string {
+	return fmt.Sprintf("%s/cards/named?fuzzy=%s&set=%s", s.baseURL, url.QueryEscape(name), url.QueryEscape(set))
+}
+
 func (s *Client) urlCardBySetAndNumber(set string, number string, lang Lang) string {
 	return fmt.Sprintf("%s/cards/%s/%s/%s", s.baseURL, set, number, lang)
 }
 
+func (s *Client) urlPrints(oracleID string) string {
+	return fmt.Sprintf("%s/cards/search?q=%s&unique=prints", s.baseURL, url.QueryEscape("oracleid:"+oracleID))
+}
+
+func (s *Client) urlCardByMtgoID(id int) string {
+	return fmt.Sprintf("%s/cards/mtgo/%d", s.baseURL, id)
+}
+
 func (s *Client) urlCardImageByName(name string) string {
 	return fmt.Sprintf("%s/cards/named?format=image&version=large&fuzzy=%s", s.baseURL, url.QueryEscape(name))
 }
@@ -1,7 +1,11 @@
 package scryfall
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestCard(t *testing.T) {
@@ -26,3 +30,42 @@ func TestNicol(t *testing.T) {
 	card := c.CardByName("Nicol Bolas, the Ravager")
 	t.Logf("%v", card.Front())
 }
+
+func TestMaxAge(t *testing.T) {
+	tests := []struct {
+		opts []ClientOption
+		fail bool
+		want string
+	}{
+		{nil, false, "1 Plains"},
+		{[]ClientOption{MaxAge(24 * time.Hour)}, false, "1 Plains"},
+		{[]ClientOption{MaxAge(time.Nanosecond)}, false, "2 Plains"},
+		{[]ClientOption{MaxAge(time.Nanosecond)}, true, "2 Plains"},
+	}
+	for _, test := range tests {
+		requests := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if test.fail && requests > 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"object":"card","name":"Plains"}`)
+		}))
+		c, err := New(append(test.opts, BaseURL(srv.URL))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.CardByName("Plains")
+		time.Sleep(time.Millisecond)
+		card := c.CardByName("Plains")
+		srv.Close()
+		name := "<nil>"
+		if card != nil {
+			name = card.Name
+		}
+		if got := fmt.Sprintf("%d %s", requests, name); test.want != got {
+			t.Errorf("want: %v, got: %v", test.want, got)
+		}
+	}
+}
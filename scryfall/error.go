@@ -1,5 +1,7 @@
 package scryfall
 
+import "fmt"
+
 // An Error object represents a failure to find information or understand the input you provided to the API.
 // Error objects are always transmitted with the appropriate 4XX or 5XX HTTP status code.
 type Error struct {
@@ -22,3 +24,15 @@ type Error struct {
 	// If your input also generated non-failure warnings, they will be provided as human-readable strings in this array.
 	Warnings []string `json:"warnings"`
 }
+
+func (e *Error) Error() string {
+	if e.Type != "" {
+		return fmt.Sprintf("%s: %s", e.Type, e.Details)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Details)
+}
+
+// IsAmbiguous reports whether the error was caused by a name that matched too many cards.
+func (e *Error) IsAmbiguous() bool {
+	return e.Type == "ambiguous"
+}
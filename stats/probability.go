@@ -0,0 +1,189 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/cognicraft/mtg"
+)
+
+// A Draw describes which cards a player has seen by a turn.
+type Draw struct {
+	// Turn is the turn of the player, the opening hand is seen by turn 1.
+	Turn int
+	// OnTheDraw is true if the player draws a card on their first turn.
+	OnTheDraw bool
+	// HandSize is the size of the opening hand after mulligans, 0 means 7.
+	// With the London mulligan seven cards are seen and the others are put
+	// on the bottom of the library.
+	HandSize int
+}
+
+// Draws returns the number of cards drawn after the opening hand.
+func (d Draw) Draws() int {
+	n := d.Turn - 1
+	if d.OnTheDraw {
+		n++
+	}
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+func (d Draw) handSize() int {
+	if d.HandSize <= 0 || d.HandSize > 7 {
+		return 7
+	}
+	return d.HandSize
+}
+
+// ByType accepts cards of a type, e.g. "Creature" or "Land".
+func ByType(t string) func(mtg.Card) bool {
+	return func(c mtg.Card) bool {
+		for _, ct := range cardTypes(c.TypeLine) {
+			if strings.EqualFold(ct, t) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ByCMC accepts nonland cards with a converted mana cost.
+func ByCMC(cmc float64) func(mtg.Card) bool {
+	return func(c mtg.Card) bool {
+		return c.CMC == cmc && c.TypeLine != "" && !ByType("Land")(c)
+	}
+}
+
+// Library returns the cards of the main deck.
+func Library(d mtg.Deck) mtg.Cards {
+	var cs mtg.Cards
+	for _, s := range d.Sections {
+		if s.Name == mtg.Main {
+			cs = append(cs, s.Cards()...)
+		}
+	}
+	return cs
+}
+
+// Hypergeometric returns the probability of drawing exactly k successes with
+// n draws from a population of size N containing K successes.
+func Hypergeometric(N, K, n, k int) float64 {
+	if k < 0 || k > K || k > n || n-k > N-K {
+		return 0
+	}
+	return math.Exp(lnChoose(K, k) + lnChoose(N-K, n-k) - lnChoose(N, n))
+}
+
+func lnChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// Probability returns the probability of holding at least k cards accepted by
+// the predicate by the turn of the draw, e.g. the odds of a two drop by turn two:
+//
+//	stats.Probability(deck, stats.ByCMC(2), 1, stats.Draw{Turn: 2})
+func Probability(d mtg.Deck, accept func(mtg.Card) bool, k int, draw Draw) float64 {
+	library := Library(d)
+	N := len(library)
+	K := 0
+	for _, c := range library {
+		if accept(c) {
+			K++
+		}
+	}
+	seen := 7
+	if seen > N {
+		seen = N
+	}
+	p := 0.0
+	for h := 0; h <= seen; h++ {
+		ph := Hypergeometric(N, K, seen, h)
+		if ph == 0 {
+			continue
+		}
+		kept := h
+		if kept > draw.handSize() {
+			kept = draw.handSize()
+		}
+		// the cards put on the bottom are not drawn again
+		n := draw.Draws()
+		if n > N-seen {
+			n = N - seen
+		}
+		for x := 0; x <= n; x++ {
+			if kept+x >= k {
+				p += ph * Hypergeometric(N-seen, K-h, n, x)
+			}
+		}
+	}
+	return p
+}
+
+// A Sampler goldfishes a deck: it shuffles the library and draws cards.
+type Sampler struct {
+	rng     *rand.Rand
+	library mtg.Cards
+}
+
+// NewSampler returns a sampler of the main deck. Samplers with the same seed
+// draw the same cards.
+func NewSampler(d mtg.Deck, seed int64) *Sampler {
+	return &Sampler{
+		rng:     rand.New(rand.NewSource(seed)),
+		library: Library(d),
+	}
+}
+
+// Hand shuffles the library and returns the opening hand, without mulligans,
+// and the cards drawn by the turn of the draw.
+func (s *Sampler) Hand(draw Draw) (mtg.Cards, mtg.Cards) {
+	cs := make(mtg.Cards, len(s.library))
+	copy(cs, s.library)
+	s.rng.Shuffle(len(cs), func(i, j int) { cs[i], cs[j] = cs[j], cs[i] })
+	seen := 7
+	if seen > len(cs) {
+		seen = len(cs)
+	}
+	n := seen + draw.Draws()
+	if n > len(cs) {
+		n = len(cs)
+	}
+	return cs[:seen], cs[seen:n]
+}
+
+// Probability estimates the probability of holding at least k cards accepted
+// by the predicate by the turn of the draw from the given number of samples.
+func (s *Sampler) Probability(accept func(mtg.Card) bool, k int, draw Draw, samples int) float64 {
+	if samples <= 0 {
+		return 0
+	}
+	hits := 0
+	for i := 0; i < samples; i++ {
+		hand, drawn := s.Hand(draw)
+		kept := count(hand, accept)
+		if kept > draw.handSize() {
+			kept = draw.handSize()
+		}
+		if kept+count(drawn, accept) >= k {
+			hits++
+		}
+	}
+	return float64(hits) / float64(samples)
+}
+
+func count(cs mtg.Cards, accept func(mtg.Card) bool) int {
+	n := 0
+	for _, c := range cs {
+		if accept(c) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cognicraft/mtg"
+)
+
+func TestProbability(t *testing.T) {
+	d := mtg.Deck{Sections: []mtg.Section{
+		{Name: mtg.Main, Entries: []mtg.Entry{
+			{Count: 4, Card: mtg.Card{Name: "Lightning Bolt", CMC: 1, TypeLine: "Instant"}},
+			{Count: 8, Card: mtg.Card{Name: "Grizzly Bears", CMC: 2, TypeLine: "Creature — Bear"}},
+			{Count: 24, Card: mtg.Card{Name: "Mountain", TypeLine: "Basic Land — Mountain"}},
+			{Count: 24, Card: mtg.Card{Name: "Hill Giant", CMC: 4, TypeLine: "Creature — Giant"}},
+		}},
+		{Name: mtg.Sideboard, Entries: []mtg.Entry{
+			{Count: 15, Card: mtg.Card{Name: "Lightning Bolt", CMC: 1, TypeLine: "Instant"}},
+		}},
+	}}
+
+	tests := []struct {
+		name   string
+		accept func(mtg.Card) bool
+		k      int
+		draw   Draw
+		want   float64
+	}{
+		{"bolt in opening hand", mtg.CardByName("Lightning Bolt"), 1, Draw{Turn: 1}, 0.3994996257446656},
+		{"bolt on the draw", mtg.CardByName("Lightning Bolt"), 1, Draw{Turn: 1, OnTheDraw: true}, 0.4448204087073324},
+		{"two drop by turn two", ByCMC(2), 1, Draw{Turn: 2}, 0.7058813338949406},
+		{"three lands by turn three", ByType("Land"), 3, Draw{Turn: 3}, 0.788654470036752},
+		{"three lands after mulligan to five", ByType("Land"), 3, Draw{Turn: 3, HandSize: 5}, 0.788654470036752},
+		{"three lands after mulligan to two", ByType("Land"), 3, Draw{Turn: 3, HandSize: 2}, 0.5682130405687367},
+		{"two bolts after mulligan to one", mtg.CardByName("Lightning Bolt"), 2, Draw{Turn: 1, HandSize: 1}, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Probability(d, test.accept, test.k, test.draw)
+			if math.Abs(test.want-got) > 1e-9 {
+				t.Errorf("want: %v, got: %v", test.want, got)
+			}
+			s := NewSampler(d, 1)
+			got = s.Probability(test.accept, test.k, test.draw, 20000)
+			if math.Abs(test.want-got) > 0.02 {
+				t.Errorf("sampled want: %v, got: %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestSamplerIsReproducible(t *testing.T) {
+	d := mtg.Deck{Sections: []mtg.Section{
+		{Name: mtg.Main, Entries: []mtg.Entry{
+			{Count: 4, Card: mtg.Card{Name: "Lightning Bolt"}},
+			{Count: 56, Card: mtg.Card{Name: "Mountain"}},
+		}},
+	}}
+	a, _ := NewSampler(d, 42).Hand(Draw{Turn: 3})
+	b, _ := NewSampler(d, 42).Hand(Draw{Turn: 3})
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			t.Fatalf("want: %v, got: %v", a, b)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Package stats computes statistics of resolved decks, i.e. decks whose cards
+// have been enriched by a mtg.Resolver.
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/cognicraft/mtg"
+)
+
+// Colors are the colors of mana in WUBRG order followed by colorless.
+var Colors = []string{"W", "U", "B", "R", "G", "C"}
+
+// Types are the card types in the order they are reported.
+var Types = []string{"Creature", "Planeswalker", "Battle", "Instant", "Sorcery", "Artifact", "Enchantment", "Land"}
+
+type Stats struct {
+	Sections []SectionCount `json:"sections"`
+	// Cards counts the cards of the main deck and the commanders.
+	Cards int `json:"cards"`
+	Lands int `json:"lands"`
+	// Unresolved counts the cards without a type line, they are left out of
+	// all the following statistics.
+	Unresolved int `json:"unresolved,omitempty"`
+	// Curve counts the nonland cards by converted mana cost.
+	Curve      []int   `json:"curve"`
+	AverageCMC float64 `json:"average_cmc"`
+	// Pips counts the colored mana symbols in the mana costs.
+	Pips map[string]int `json:"pips"`
+	// Sources counts the lands that can produce mana of a color.
+	Sources map[string]int `json:"sources"`
+	// Types counts the cards by type, a card with several types is counted
+	// for each of them.
+	Types map[string]int `json:"types"`
+}
+
+type SectionCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func Compute(d mtg.Deck) Stats {
+	s := Stats{
+		Pips:    map[string]int{},
+		Sources: map[string]int{},
+		Types:   map[string]int{},
+	}
+	nonLands := 0
+	totalCMC := 0.0
+	for _, sec := range d.Sections {
+		s.Sections = append(s.Sections, SectionCount{Name: sec.Name, Count: sec.Count()})
+		if sec.Name != mtg.Main && sec.Name != mtg.Commander {
+			continue
+		}
+		for _, e := range sec.Entries {
+			c := e.Card
+			s.Cards += e.Count
+			if c.TypeLine == "" {
+				s.Unresolved += e.Count
+				continue
+			}
+			types := cardTypes(c.TypeLine)
+			for _, t := range types {
+				s.Types[t] += e.Count
+			}
+			if contains(types, "Land") {
+				s.Lands += e.Count
+				for _, color := range Colors {
+					if contains(c.ProducedMana, color) {
+						s.Sources[color] += e.Count
+					}
+				}
+				continue
+			}
+			for color, n := range pips(c.ManaCost) {
+				s.Pips[color] += n * e.Count
+			}
+			cmc := int(c.CMC)
+			for len(s.Curve) <= cmc {
+				s.Curve = append(s.Curve, 0)
+			}
+			s.Curve[cmc] += e.Count
+			nonLands += e.Count
+			totalCMC += c.CMC * float64(e.Count)
+		}
+	}
+	if nonLands > 0 {
+		s.AverageCMC = totalCMC / float64(nonLands)
+	}
+	return s
+}
+
+// cardTypes returns the card types of the front face of a type line like
+// "Legendary Creature — Fungus".
+func cardTypes(typeLine string) []string {
+	if i := strings.Index(typeLine, " // "); i >= 0 {
+		typeLine = typeLine[:i]
+	}
+	if i := strings.Index(typeLine, "—"); i >= 0 {
+		typeLine = typeLine[:i]
+	}
+	var ts []string
+	for _, f := range strings.Fields(typeLine) {
+		if contains(Types, f) {
+			ts = append(ts, f)
+		}
+	}
+	return ts
+}
+
+var manaSymbol = regexp.MustCompile(`\{([^}]+)\}`)
+
+// pips counts the colored symbols of a mana cost. Hybrid symbols count for
+// each of their colors.
+func pips(manaCost string) map[string]int {
+	ps := map[string]int{}
+	for _, m := range manaSymbol.FindAllStringSubmatch(manaCost, -1) {
+		for _, p := range strings.Split(m[1], "/") {
+			if contains(Colors, p) {
+				ps[p]++
+			}
+		}
+	}
+	return ps
+}
+
+func contains(ss []string, s string) bool {
+	for _, o := range ss {
+		if o == s {
+			return true
+		}
+	}
+	return false
+}
+
+func WriteJSON(w io.Writer, s Stats) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+func WriteText(w io.Writer, s Stats) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cards: %d\n", s.Cards)
+	for _, sec := range s.Sections {
+		fmt.Fprintf(&b, "  %-12s %3d\n", sec.Name, sec.Count)
+	}
+	fmt.Fprintf(&b, "Lands: %d\n", s.Lands)
+	if s.Unresolved > 0 {
+		fmt.Fprintf(&b, "Unresolved: %d\n", s.Unresolved)
+	}
+	fmt.Fprintf(&b, "Average CMC: %.2f (without lands)\n", s.AverageCMC)
+
+	fmt.Fprintf(&b, "\nMana curve\n")
+	for cmc, n := range s.Curve {
+		fmt.Fprintf(&b, "%s\n", strings.TrimRight(fmt.Sprintf("  %2d %3d %s", cmc, n, strings.Repeat("#", n)), " "))
+	}
+
+	fmt.Fprintf(&b, "\nTypes\n")
+	for _, t := range Types {
+		if n := s.Types[t]; n > 0 {
+			fmt.Fprintf(&b, "  %-12s %3d\n", t, n)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nColors     pips  sources\n")
+	for _, color := range Colors {
+		if s.Pips[color] == 0 && s.Sources[color] == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "  %-6s %6d %8d\n", color, s.Pips[color], s.Sources[color])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,80 @@
+package stats
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/cognicraft/mtg"
+)
+
+func TestCompute(t *testing.T) {
+	bolt := mtg.Card{Name: "Lightning Bolt", ManaCost: "{R}", CMC: 1, TypeLine: "Instant"}
+	helix := mtg.Card{Name: "Lightning Helix", ManaCost: "{R}{W}", CMC: 2, TypeLine: "Instant"}
+	boros := mtg.Card{Name: "Boros Reckoner", ManaCost: "{R/W}{R/W}{R/W}", CMC: 3, TypeLine: "Creature — Minotaur Wizard"}
+	mountain := mtg.Card{Name: "Mountain", TypeLine: "Basic Land — Mountain", ProducedMana: []string{"R"}}
+	foundry := mtg.Card{Name: "Sacred Foundry", TypeLine: "Land — Mountain Plains", ProducedMana: []string{"R", "W"}}
+	d := mtg.Deck{Sections: []mtg.Section{
+		{Name: mtg.Main, Entries: []mtg.Entry{
+			{Count: 4, Card: bolt},
+			{Count: 2, Card: helix},
+			{Count: 2, Card: boros},
+			{Count: 8, Card: mountain},
+			{Count: 4, Card: foundry},
+			{Count: 1, Card: mtg.Card{Name: "Lightnig Bolt"}},
+		}},
+		{Name: mtg.Sideboard, Entries: []mtg.Entry{
+			{Count: 3, Card: helix},
+		}},
+	}}
+
+	s := Compute(d)
+	if s.Cards != 21 || s.Lands != 12 || s.Unresolved != 1 {
+		t.Errorf("want: 21 cards, 12 lands, 1 unresolved, got: %d, %d, %d", s.Cards, s.Lands, s.Unresolved)
+	}
+	if want, got := "[0 4 2 2]", fmt.Sprint(s.Curve); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	if want, got := 1.75, s.AverageCMC; want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	if want, got := "map[R:12 W:8]", fmt.Sprint(s.Pips); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	if want, got := "map[R:12 W:4]", fmt.Sprint(s.Sources); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	if want, got := "map[Creature:2 Instant:6 Land:12]", fmt.Sprint(s.Types); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteText(buf, s); err != nil {
+		t.Fatal(err)
+	}
+	want := `Cards: 21
+  Main          21
+  Sideboard      3
+Lands: 12
+Unresolved: 1
+Average CMC: 1.75 (without lands)
+
+Mana curve
+   0   0
+   1   4 ####
+   2   2 ##
+   3   2 ##
+
+Types
+  Creature       2
+  Instant        6
+  Land          12
+
+Colors     pips  sources
+  W           8        4
+  R          12       12
+`
+	if got := buf.String(); want != got {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
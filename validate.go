@@ -0,0 +1,201 @@
+package mtg
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cognicraft/mtg/scryfall"
+)
+
+// A Format describes the deck construction rules of a play format. The name is
+// the key of the format in the legalities of scryfall cards.
+type Format struct {
+	Name             string
+	MinDeckSize      int
+	MaxDeckSize      int // 0 means there is no maximum
+	MaxSideboardSize int
+	MaxCopies        int
+	// Commander formats count the commander towards the deck and keep the
+	// companion outside of it.
+	Commander bool
+}
+
+var Formats = []Format{
+	{Name: "standard", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "pioneer", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "modern", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "legacy", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "vintage", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "pauper", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "historic", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "explorer", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "timeless", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "alchemy", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "penny", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "premodern", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "oldschool", MinDeckSize: 60, MaxSideboardSize: 15, MaxCopies: 4},
+	{Name: "commander", MinDeckSize: 100, MaxDeckSize: 100, MaxCopies: 1, Commander: true},
+	{Name: "duel", MinDeckSize: 100, MaxDeckSize: 100, MaxCopies: 1, Commander: true},
+	{Name: "paupercommander", MinDeckSize: 100, MaxDeckSize: 100, MaxCopies: 1, Commander: true},
+	{Name: "brawl", MinDeckSize: 100, MaxDeckSize: 100, MaxCopies: 1, Commander: true},
+	{Name: "standardbrawl", MinDeckSize: 60, MaxDeckSize: 60, MaxCopies: 1, Commander: true},
+	{Name: "oathbreaker", MinDeckSize: 60, MaxDeckSize: 60, MaxCopies: 1, Commander: true},
+}
+
+func FormatByName(name string) (Format, bool) {
+	for _, f := range Formats {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return Format{}, false
+}
+
+// A Violation is a reason why a deck is not valid. Violations concerning the
+// deck as a whole have no card.
+type Violation struct {
+	Card    string
+	Section string
+	Reason  string
+}
+
+func (v Violation) String() string {
+	if v.Card == "" {
+		return v.Reason
+	}
+	return fmt.Sprintf("%s: %s", v.Card, v.Reason)
+}
+
+// ValidateDeck checks the deck against the rules of the format. Cards are looked
+// up with the resolver.
+func ValidateDeck(r *Resolver, d Deck, f Format) []Violation {
+	var vs []Violation
+	resolved, rs := r.Resolve(d)
+	for _, res := range rs {
+		switch res.Status {
+		case NotFound, Ambiguous:
+			vs = append(vs, Violation{Card: res.Entry.Card.Name, Section: res.Section, Reason: string(res.Status)})
+		}
+	}
+
+	deckSize, sideboardSize := 0, 0
+	copies := map[string]int{}
+	cards := map[string]*scryfall.Card{}
+	var names []string
+	k := 0
+	for _, s := range resolved.Sections {
+		// the resolutions of the entries of the section
+		srs := rs[k : k+len(s.Entries)]
+		k += len(s.Entries)
+		switch {
+		case s.Name == Maybeboard:
+			continue
+		case s.Name == Main, f.Commander && s.Name == Commander:
+			deckSize += s.Count()
+		case f.Commander && s.Name == Companion:
+		default:
+			sideboardSize += s.Count()
+		}
+		for i, e := range s.Entries {
+			sc := r.Card(e.Card)
+			if sc == nil {
+				if srs[i].Status == Resolved || srs[i].Status == Corrected {
+					vs = append(vs, Violation{Card: e.Card.Name, Section: s.Name, Reason: "cannot be checked"})
+				}
+				continue
+			}
+			if _, ok := copies[e.Card.Name]; !ok {
+				names = append(names, e.Card.Name)
+				cards[e.Card.Name] = sc
+			}
+			copies[e.Card.Name] += e.Count
+		}
+	}
+
+	if deckSize < f.MinDeckSize {
+		vs = append(vs, Violation{Section: Main, Reason: fmt.Sprintf("deck has %d cards, at least %d required", deckSize, f.MinDeckSize)})
+	}
+	if f.MaxDeckSize > 0 && deckSize > f.MaxDeckSize {
+		vs = append(vs, Violation{Section: Main, Reason: fmt.Sprintf("deck has %d cards, at most %d allowed", deckSize, f.MaxDeckSize)})
+	}
+	if sideboardSize > f.MaxSideboardSize {
+		vs = append(vs, Violation{Section: Sideboard, Reason: fmt.Sprintf("sideboard has %d cards, at most %d allowed", sideboardSize, f.MaxSideboardSize)})
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		sc := cards[name]
+		n := copies[name]
+		switch sc.Legalities[f.Name] {
+		case scryfall.LegalityLegal:
+		case scryfall.LegalityRestricted:
+			if n > 1 {
+				vs = append(vs, Violation{Card: name, Reason: fmt.Sprintf("restricted, %d copies, at most 1 allowed", n)})
+			}
+			continue
+		case scryfall.LegalityBanned:
+			vs = append(vs, Violation{Card: name, Reason: "banned"})
+		default:
+			vs = append(vs, Violation{Card: name, Reason: "not legal"})
+		}
+		if max := maxCopies(sc, f); max > 0 && n > max {
+			vs = append(vs, Violation{Card: name, Reason: fmt.Sprintf("%d copies, at most %d allowed", n, max)})
+		}
+	}
+	// an oathbreaker is a planeswalker and follows rules of its own
+	if f.Commander && f.Name != "oathbreaker" {
+		vs = append(vs, commanderViolations(r, resolved, f)...)
+	}
+	return vs
+}
+
+var anyNumber = regexp.MustCompile(`(?i)a deck can have (any number of|up to (\w+)) cards named`)
+
+var numbers = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+}
+
+// maxCopies returns the number of copies of a card a deck may contain, 0 means any number.
+func maxCopies(sc *scryfall.Card, f Format) int {
+	if IsBasicLand(sc) {
+		return 0
+	}
+	if m := anyNumber.FindStringSubmatch(oracleText(sc)); m != nil {
+		if m[2] == "" {
+			return 0
+		}
+		if n, ok := numbers[strings.ToLower(m[2])]; ok {
+			return n
+		}
+	}
+	return f.MaxCopies
+}
+
+var basicLandNames = map[string]bool{
+	"plains": true, "island": true, "swamp": true, "mountain": true, "forest": true, "wastes": true,
+	"snow-covered plains": true, "snow-covered island": true, "snow-covered swamp": true,
+	"snow-covered mountain": true, "snow-covered forest": true, "snow-covered wastes": true,
+}
+
+func IsBasicLand(sc *scryfall.Card) bool {
+	return isBasicLandName(sc.Name)
+}
+
+// isBasicLandName reports whether the name is the name of a basic land, for
+// cards that have not been resolved.
+func isBasicLandName(name string) bool {
+	return basicLandNames[strings.ToLower(name)]
+}
+
+func oracleText(sc *scryfall.Card) string {
+	if sc.OracleText != "" || len(sc.CardFaces) == 0 {
+		return sc.OracleText
+	}
+	var ts []string
+	for _, f := range sc.CardFaces {
+		ts = append(ts, f.OracleText)
+	}
+	return strings.Join(ts, "\n")
+}
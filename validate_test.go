@@ -0,0 +1,76 @@
+package mtg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateDeck(t *testing.T) {
+	client, done := newTestScryfall(t)
+	defer done()
+
+	raw := `5 Lightning Bolt
+15 Mountain
+5 Zndrsplt, Eye of Wisdom
+30 Relentless Rats
+1 Black Lotus
+SB: 16 Mountain
+`
+	d, err := ParseDeck(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewResolver(client)
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{
+			format: "modern",
+			want: []string{
+				"deck has 56 cards, at least 60 required",
+				"sideboard has 16 cards, at most 15 allowed",
+				"Black Lotus: not legal",
+				"Lightning Bolt: 5 copies, at most 4 allowed",
+				"Zndrsplt, Eye of Wisdom: not legal",
+				"Zndrsplt, Eye of Wisdom: 5 copies, at most 4 allowed",
+			},
+		},
+		{
+			format: "vintage",
+			want: []string{
+				"deck has 56 cards, at least 60 required",
+				"sideboard has 16 cards, at most 15 allowed",
+				"Lightning Bolt: 5 copies, at most 4 allowed",
+				"Zndrsplt, Eye of Wisdom: 5 copies, at most 4 allowed",
+			},
+		},
+		{
+			format: "legacy",
+			want: []string{
+				"deck has 56 cards, at least 60 required",
+				"sideboard has 16 cards, at most 15 allowed",
+				"Black Lotus: banned",
+				"Lightning Bolt: 5 copies, at most 4 allowed",
+				"Zndrsplt, Eye of Wisdom: 5 copies, at most 4 allowed",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			f, ok := FormatByName(test.format)
+			if !ok {
+				t.Fatalf("unknown format: %s", test.format)
+			}
+			vs := ValidateDeck(r, d, f)
+			var got []string
+			for _, v := range vs {
+				got = append(got, v.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+				t.Errorf("want:\n%s\ngot:\n%s", strings.Join(test.want, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package mtg
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// WriteDeck writes the deck in the plain text format understood by ParseDeck.
+// Sections other than the known ones are written with headers like Tokens:.
+func WriteDeck(w io.Writer, d Deck) error {
+	bw := bufio.NewWriter(w)
+	first := true
+	for _, s := range d.Sections {
+		if len(s.Entries) == 0 {
+			continue
+		}
+		if !first {
+			fmt.Fprintln(bw)
+		}
+		first = false
+		if _, ok := SectionName(s.Name); ok {
+			fmt.Fprintf(bw, "//%s\n", s.Name)
+		} else {
+			fmt.Fprintf(bw, "%s:\n", s.Name)
+		}
+		for _, e := range s.Entries {
+			fmt.Fprintln(bw, FormatEntry(e))
+		}
+	}
+	return bw.Flush()
+}
+
+func FormatEntry(e Entry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d ", e.Count)
+	if e.Card.Version != nil && e.Card.Version.Set != "" {
+		fmt.Fprintf(&b, "[%s] ", e.Card.Version)
+	}
+	b.WriteString(e.Card.Name)
+	if e.Comment != "" {
+		fmt.Fprintf(&b, " # %s", e.Comment)
+	}
+	return b.String()
+}
+
+// Merge combines entries of the same card and printing within each section.
+func (d *Deck) Merge() {
+	for i := range d.Sections {
+		s := &d.Sections[i]
+		var merged []Entry
+		for _, e := range s.Entries {
+			found := false
+			for j := range merged {
+				if sameCard(e.Card)(merged[j].Card) {
+					merged[j].Count += e.Count
+					if merged[j].Comment == "" {
+						merged[j].Comment = e.Comment
+						merged[j].Tags = e.Tags
+					}
+					found = true
+					break
+				}
+			}
+			if !found {
+				merged = append(merged, e)
+			}
+		}
+		s.Entries = merged
+	}
+}
+
+// Sort orders the entries within each section by card name and printing.
+func (d *Deck) Sort() {
+	for i := range d.Sections {
+		es := d.Sections[i].Entries
+		sort.SliceStable(es, func(a, b int) bool {
+			na, nb := strings.ToLower(es[a].Card.Name), strings.ToLower(es[b].Card.Name)
+			if na != nb {
+				return na < nb
+			}
+			return versionString(es[a].Card.Version) < versionString(es[b].Card.Version)
+		})
+	}
+}
+
+func versionString(v *Version) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
@@ -0,0 +1,82 @@
+package mtg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteDeck(t *testing.T) {
+	raw := `//Commander
+1 [DOM:205] Slimefoot, the Stowaway # !Commander
+
+//Main
+1 [DOM] Llanowar Elves
+37 Forest # basics
+1 Sol Ring
+
+//Sideboard
+2 Naturalize
+
+Tokens:
+4 Saproling
+`
+
+	d, err := ParseDeck(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := WriteDeck(buf, d); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != raw {
+		t.Errorf("want:\n%s\ngot:\n%s", raw, got)
+	}
+
+	rd, err := ParseDeck(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rd.Sections) != len(d.Sections) {
+		t.Fatalf("want: %d, got: %d", len(d.Sections), len(rd.Sections))
+	}
+	for i, s := range d.Sections {
+		if rd.Sections[i].Name != s.Name || rd.Sections[i].Count() != s.Count() {
+			t.Errorf("want: %s (%d), got: %s (%d)", s.Name, s.Count(), rd.Sections[i].Name, rd.Sections[i].Count())
+		}
+	}
+}
+
+func TestWriteDeckNormalized(t *testing.T) {
+	raw := `
+2 Sol Ring
+1 [DOM:205] Slimefoot, the Stowaway
+4 Forest
+1 Sol Ring
+SB: 1 Naturalize
+1 Forest
+`
+	want := `//Main
+5 Forest
+1 [DOM:205] Slimefoot, the Stowaway
+3 Sol Ring
+
+//Sideboard
+1 Naturalize
+`
+
+	d, err := ParseDeck(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Merge()
+	d.Sort()
+	buf := &bytes.Buffer{}
+	if err := WriteDeck(buf, d); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}